@@ -0,0 +1,158 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// errBatchOrder is returned when FindInBatches is combined with an order or offset of its own.
+var errBatchOrder = errors.New("FindInBatches orders by _id and cannot be combined with Sort or Skip")
+
+// BatchError is returned by FindInBatches when a batch query or the callback fails.
+// LastID is the _id of the last document of the last batch processed successfully, or nil if no batch
+// completed, so the walk can be restarted with ResumeAfter.
+//
+// Example:
+//
+//	err := qb.Find(filter).FindInBatches(ctx, 1000, process)
+//	var batchErr *morm.BatchError
+//	if errors.As(err, &batchErr) {
+//	  saveCheckpoint(batchErr.LastID)
+//	}
+type BatchError struct {
+	LastID interface{}
+	Err    error
+}
+
+// Error returns the error message including the last processed _id.
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch after _id %v: %v", e.LastID, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// ResumeAfter makes FindInBatches start after the document with the given _id,
+// typically the LastID of a BatchError or a checkpoint saved by the callback.
+func (qb *CollectQueryBuilder) ResumeAfter(id interface{}) *CollectQueryBuilder {
+	qb.resumeAfter = id
+	return qb
+}
+
+// FindInBatches walks the documents matching the find query in batches of size documents,
+// calling fn with each batch and its number, starting at 1.
+//
+// The documents are walked in _id order with keyset pagination: each batch queries the documents whose _id
+// is greater than the last one seen, so the cost of a batch does not grow with the number of batches.
+// A limit set on the query caps the total number of documents walked, and _id is always kept by its projection.
+// The walk stops at the first error, which is returned as a *BatchError.
+//
+// Parameters:
+//   - ctx: The context.Context used for the queries.
+//   - size: The number of documents per batch.
+//   - fn: The callback processing a batch. Returning an error stops the walk.
+//
+// Returns:
+//   - error: A *BatchError if a batch query or the callback failed, or an error if the query is invalid.
+//
+// Example:
+//
+//	err := qb.Find(bson.M{"migrated": false}).FindInBatches(ctx, 1000, func(batch []interface{}, n int) error {
+//	  return migrate(batch)
+//	})
+func (qb *CollectQueryBuilder) FindInBatches(ctx context.Context, size int, fn func(batch []interface{}, n int) error) error {
+	return findInBatches(ctx, qb, size, func() interface{} {
+		return reflect.New(qb.c.modelType.Elem()).Interface()
+	}, fn)
+}
+
+// findInBatches runs the batch queries of FindInBatches, decoding documents into values returned by newResult.
+func findInBatches(ctx context.Context, qb *CollectQueryBuilder, size int, newResult func() interface{}, fn func(batch []interface{}, n int) error) error {
+	if size <= 0 {
+		return errors.New("batch size must be positive")
+	}
+	if qb.sort != nil || qb.skip != 0 {
+		return errBatchOrder
+	}
+
+	// A limit on the query caps the total number of documents walked
+	remaining := qb.limit
+	lastID := qb.resumeAfter
+	for n := 1; ; n++ {
+		batchQuery := *qb
+		batchQuery.sort = bson.D{{Key: "_id", Value: 1}}
+		batchQuery.limit = int64(size)
+		batchQuery.projection = projectionWithID(qb.projection)
+		if remaining > 0 && remaining < batchQuery.limit {
+			batchQuery.limit = remaining
+		}
+		if lastID != nil {
+			batchQuery.whereClauses = append(append([]bson.D(nil), qb.whereClauses...),
+				bson.D{{Key: "_id", Value: bson.D{{Key: "$gt", Value: lastID}}}})
+		}
+
+		batch, err := find(ctx, &batchQuery, newResult)
+		if err != nil {
+			return &BatchError{LastID: lastID, Err: err}
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		id, ok := documentID(batch[len(batch)-1])
+		if !ok {
+			return &BatchError{LastID: lastID, Err: errors.New("model has no _id field")}
+		}
+		if id == nil || reflect.ValueOf(id).IsZero() {
+			return &BatchError{LastID: lastID, Err: errors.New("batch document has no _id")}
+		}
+
+		if err := fn(batch, n); err != nil {
+			return &BatchError{LastID: lastID, Err: err}
+		}
+		lastID = id
+
+		remaining -= int64(len(batch))
+		if len(batch) < size || remaining == 0 {
+			return nil
+		}
+	}
+}
+
+// projectionWithID returns the projection without its _id entry, so _id is returned by the batch queries.
+func projectionWithID(projection bson.D) bson.D {
+	if projection == nil {
+		return nil
+	}
+
+	var kept bson.D
+	for _, elem := range projection {
+		if elem.Key != "_id" {
+			kept = append(kept, elem)
+		}
+	}
+	return kept
+}
+
+// documentID returns the _id of a decoded model.
+func documentID(model interface{}) (interface{}, bool) {
+	value := reflect.ValueOf(model)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	id, ok := fieldValueByBSONName(value, "_id")
+	if !ok {
+		return nil, false
+	}
+	return id.Interface(), true
+}
@@ -0,0 +1,29 @@
+package morm
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestProjectionWithID tests that batch projections never exclude _id
+func TestProjectionWithID(t *testing.T) {
+	tests := []struct {
+		name       string
+		projection bson.D
+		expected   bson.D
+	}{
+		{name: "none", projection: nil, expected: nil},
+		{name: "inclusion", projection: bson.D{{Key: "name", Value: 1}}, expected: bson.D{{Key: "name", Value: 1}}},
+		{name: "excluded id", projection: bson.D{{Key: "_id", Value: 0}}, expected: nil},
+		{name: "excluded id with fields", projection: bson.D{{Key: "_id", Value: 0}, {Key: "name", Value: 1}}, expected: bson.D{{Key: "name", Value: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := projectionWithID(tt.projection); !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
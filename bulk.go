@@ -0,0 +1,391 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Bulk accumulates inserts, updates, replaces and deletes and executes them with BulkWrite.
+// Every operation goes through the same hooks, timestamps and validation as the single-document method it mirrors,
+// and is identified by its index, the order in which it was added.
+type Bulk struct {
+	qb  *CollectQueryBuilder
+	ops []bulkOp
+}
+
+// bulkOp is an operation added to a Bulk. The kind of operation is the hook event it runs.
+type bulkOp struct {
+	name     string
+	filter   interface{}
+	update   interface{}
+	document interface{}
+	upsert   bool
+}
+
+// BulkResult reports the outcome of Bulk.Execute.
+type BulkResult struct {
+	// InsertedCount is the number of documents inserted by InsertOne operations.
+	InsertedCount int64
+	// MatchedCount is the number of documents matched by update and replace operations.
+	MatchedCount int64
+	// ModifiedCount is the number of documents modified by update and replace operations.
+	ModifiedCount int64
+	// DeletedCount is the number of documents deleted.
+	DeletedCount int64
+	// UpsertedCount is the number of documents inserted by upserts.
+	UpsertedCount int64
+	// UpsertedIDs maps the index of each upsert that inserted a document to the document's _id.
+	UpsertedIDs map[int]interface{}
+}
+
+// Bulk starts a bulk write on the collection.
+//
+// Example:
+//
+//	res, err := qb.Bulk().
+//	  InsertOne(&User{Name: "ada"}).
+//	  UpdateOne(bson.M{"name": "alan"}, morm.Set("active", true)).
+//	  UpsertOne(bson.M{"email": email}, morm.Set("name", name)).
+//	  DeleteMany(bson.M{"active": false}).
+//	  Execute(ctx, morm.Ordered(false))
+func (qb *CollectQueryBuilder) Bulk() *Bulk {
+	return &Bulk{qb: qb}
+}
+
+// Bulk starts a bulk write on the collection. See CollectQueryBuilder.Bulk.
+func (tc *TypedCollection[T]) Bulk() *Bulk {
+	return tc.Query().Bulk()
+}
+
+// InsertOne adds the insert of a document, prepared like Create.
+func (b *Bulk) InsertOne(document interface{}) *Bulk {
+	return b.add(bulkOp{name: HookSave, document: document})
+}
+
+// UpdateOne adds an update of the first document matching the filter, composed like UpdateOne.
+func (b *Bulk) UpdateOne(filter interface{}, update interface{}) *Bulk {
+	return b.add(bulkOp{name: HookUpdateOne, filter: filter, update: update})
+}
+
+// UpdateMany adds an update of every document matching the filter, composed like Update.
+func (b *Bulk) UpdateMany(filter interface{}, update interface{}) *Bulk {
+	return b.add(bulkOp{name: HookUpdate, filter: filter, update: update})
+}
+
+// UpsertOne adds an update of the first document matching the filter that inserts a document when none matches.
+// The created-at timestamp is written with $setOnInsert unless the update sets it.
+func (b *Bulk) UpsertOne(filter interface{}, update interface{}) *Bulk {
+	return b.add(bulkOp{name: HookUpdateOne, filter: filter, update: update, upsert: true})
+}
+
+// ReplaceOne adds the replacement of the first document matching the filter.
+// The replacement is validated and gets its defaults and updated-at timestamp. A zero created-at timestamp is
+// left out of the written document, so the replaced document keeps its creation time; a set one is written as is.
+func (b *Bulk) ReplaceOne(filter interface{}, replacement interface{}) *Bulk {
+	return b.add(bulkOp{name: HookReplaceOne, filter: filter, document: replacement})
+}
+
+// UpsertReplace adds the replacement of the first document matching the filter that inserts
+// the replacement when none matches.
+// A zero created-at timestamp is set to the current time, which records the creation time of an inserted
+// document but also overwrites the creation time of a replaced one. Set it from the stored document, if any,
+// to keep the original value.
+func (b *Bulk) UpsertReplace(filter interface{}, replacement interface{}) *Bulk {
+	return b.add(bulkOp{name: HookReplaceOne, filter: filter, document: replacement, upsert: true})
+}
+
+// DeleteOne adds the delete of the first document matching the filter.
+func (b *Bulk) DeleteOne(filter interface{}) *Bulk {
+	return b.add(bulkOp{name: HookDeleteOne, filter: filter})
+}
+
+// DeleteMany adds the delete of every document matching the filter.
+func (b *Bulk) DeleteMany(filter interface{}) *Bulk {
+	return b.add(bulkOp{name: HookDeleteMany, filter: filter})
+}
+
+// Len returns the number of operations added to the bulk write.
+func (b *Bulk) Len() int {
+	return len(b.ops)
+}
+
+// add appends an operation to the bulk write.
+func (b *Bulk) add(op bulkOp) *Bulk {
+	b.ops = append(b.ops, op)
+	return b
+}
+
+// Execute runs the operations with BulkWrite, which the driver splits by the server's maxWriteBatchSize.
+// Each operation first runs its pre hooks and Before model hook, gets its timestamps and is validated;
+// operations failing these steps are not sent. The post hooks and After model hooks of the operations that
+// succeeded run once their chunk is written, with the chunk's *mongo.BulkWriteResult as the operation result.
+//
+// In ordered mode, the default, execution stops at the first failed operation. With Ordered(false), every
+// valid operation is attempted and all failures are reported.
+//
+// Parameters:
+//   - ctx: The context.Context used for the writes.
+//   - opts: Ordered and ChunkSize options. ChunkSize sends the operations in several BulkWrite calls.
+//
+// Returns:
+//   - *BulkResult: The counts of the writes that were applied, also when some operations failed.
+//   - error: A *BulkError mapping the index of each failed operation to its cause,
+//     or an error if a chunk failed as a whole.
+func (b *Bulk) Execute(ctx context.Context, opts ...BulkOption) (*BulkResult, error) {
+	config := newBulkConfig(opts)
+	result := &BulkResult{UpsertedIDs: map[int]interface{}{}}
+	failed := map[int]error{}
+
+	// Prepare and validate every operation before anything is written
+	var ops []*Operation
+	var models []mongo.WriteModel
+	var indexes []int
+	for i, bulkOp := range b.ops {
+		op, model, err := b.prepare(ctx, bulkOp)
+		if err != nil {
+			failed[i] = err
+			if config.ordered {
+				break
+			}
+			continue
+		}
+		ops = append(ops, op)
+		models = append(models, model)
+		indexes = append(indexes, i)
+	}
+
+	// The driver splits each BulkWrite by the server's maxWriteBatchSize, so chunks are only needed on request
+	chunkSize := len(models)
+	if config.chunkSize > 0 && config.chunkSize < chunkSize {
+		chunkSize = config.chunkSize
+	}
+
+	for start := 0; start < len(models); start += chunkSize {
+		end := start + chunkSize
+		if end > len(models) {
+			end = len(models)
+		}
+
+		res, err := b.qb.c.collection.BulkWrite(ctx, models[start:end], options.BulkWrite().SetOrdered(config.ordered))
+		if res != nil {
+			result.add(res, indexes[start:end])
+		}
+		var bulkErr mongo.BulkWriteException
+		if err != nil && (!errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil) {
+			return result, err
+		}
+
+		// An ordered bulk write stops at its first write error
+		written := end - start
+		chunkFailed := map[int]bool{}
+		for _, writeErr := range bulkErr.WriteErrors {
+			cause := writeErr.WriteError
+			cause.Index = indexes[start+writeErr.Index]
+			failed[cause.Index] = cause
+			chunkFailed[writeErr.Index] = true
+			if config.ordered && writeErr.Index < written {
+				written = writeErr.Index
+			}
+		}
+
+		for j := 0; j < written; j++ {
+			if chunkFailed[j] {
+				continue
+			}
+			if err := b.after(ctx, ops[start+j], res); err != nil {
+				failed[indexes[start+j]] = err
+			}
+		}
+
+		if config.ordered && len(bulkErr.WriteErrors) > 0 {
+			break
+		}
+	}
+
+	if len(failed) > 0 {
+		return result, &BulkError{Errors: failed}
+	}
+	return result, nil
+}
+
+// prepare runs the steps of an operation that precede the write and returns its write model.
+func (b *Bulk) prepare(ctx context.Context, bulkOp bulkOp) (*Operation, mongo.WriteModel, error) {
+	qb := b.qb
+	switch bulkOp.name {
+	case HookSave:
+		op, err := prepareInsert(ctx, qb, bulkOp.document)
+		if err != nil {
+			return nil, nil, err
+		}
+		return op, mongo.NewInsertOneModel().SetDocument(op.Document), nil
+
+	case HookUpdateOne, HookUpdate:
+		op := &Operation{Name: bulkOp.name, Filter: bulkOp.filter, Update: bulkOp.update}
+		if err := qb.beforeUpdate(ctx, op); err != nil {
+			return nil, nil, err
+		}
+		update, err := qb.c.composeUpdate(op.Update)
+		if err != nil {
+			return nil, nil, err
+		}
+		if bulkOp.upsert {
+			update = qb.c.composeUpsert(update)
+		}
+
+		if op.Name == HookUpdate {
+			return op, mongo.NewUpdateManyModel().SetFilter(op.Filter).SetUpdate(update).SetUpsert(bulkOp.upsert), nil
+		}
+		return op, mongo.NewUpdateOneModel().SetFilter(op.Filter).SetUpdate(update).SetUpsert(bulkOp.upsert), nil
+
+	case HookReplaceOne:
+		filter, err := qb.writeFilter(bulkOp.filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		op := &Operation{Name: bulkOp.name, Filter: filter, Document: bulkOp.document}
+		if err := qb.c.runHooks(ctx, true, op); err != nil {
+			return nil, nil, err
+		}
+		if err := callModelHook(ctx, op.Document, "BeforeUpdate"); err != nil {
+			return nil, nil, err
+		}
+		replacement, err := qb.c.prepareReplace(op.Document, bulkOp.upsert)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := validateDocument(ctx, qb, op.Document); err != nil {
+			return nil, nil, err
+		}
+		return op, mongo.NewReplaceOneModel().SetFilter(op.Filter).SetReplacement(replacement).SetUpsert(bulkOp.upsert), nil
+
+	default:
+		op := &Operation{Name: bulkOp.name, Filter: bulkOp.filter}
+		if err := qb.beforeDelete(ctx, op); err != nil {
+			return nil, nil, err
+		}
+		if op.Name == HookDeleteMany {
+			return op, mongo.NewDeleteManyModel().SetFilter(op.Filter), nil
+		}
+		return op, mongo.NewDeleteOneModel().SetFilter(op.Filter), nil
+	}
+}
+
+// after runs the steps of an operation that follow a successful write.
+func (b *Bulk) after(ctx context.Context, op *Operation, res *mongo.BulkWriteResult) error {
+	qb := b.qb
+	switch op.Name {
+	case HookSave:
+		id, _ := documentID(op.Document)
+		objectID, _ := id.(primitive.ObjectID)
+		return afterInsert(ctx, qb, op, objectID)
+	case HookUpdateOne, HookUpdate:
+		op.Result = res
+		return qb.afterUpdate(ctx, op, op.Update)
+	case HookReplaceOne:
+		op.Result = res
+		return qb.afterUpdate(ctx, op, op.Document)
+	default:
+		op.Result = res
+		return qb.afterDelete(ctx, op, nil)
+	}
+}
+
+// add adds the counts of a chunk's result, mapping its upserted IDs to the indexes of the operations.
+func (r *BulkResult) add(res *mongo.BulkWriteResult, indexes []int) {
+	r.InsertedCount += res.InsertedCount
+	r.MatchedCount += res.MatchedCount
+	r.ModifiedCount += res.ModifiedCount
+	r.DeletedCount += res.DeletedCount
+	r.UpsertedCount += res.UpsertedCount
+	for i, id := range res.UpsertedIDs {
+		if int(i) < len(indexes) {
+			r.UpsertedIDs[indexes[i]] = id
+		}
+	}
+}
+
+// composeUpsert returns a composed update document with the created-at timestamp added to its $setOnInsert,
+// unless timestamps are disabled or the update already writes it. Pipelines are returned unchanged.
+// The update is copied, since it may be the caller's own document.
+func (c *Collect) composeUpsert(update interface{}) interface{} {
+	policy := c.timestampPolicy()
+	doc, ok := update.(bson.D)
+	if !ok || policy.Disabled || writesField(doc, policy.CreatedAt) {
+		return update
+	}
+
+	created := bson.E{Key: policy.CreatedAt, Value: policy.now()}
+	composed := make(bson.D, 0, len(doc)+1)
+	added := false
+	for _, elem := range doc {
+		if elem.Key == "$setOnInsert" && !added {
+			setOnInsert, err := toDocument(elem.Value)
+			if err != nil {
+				return update
+			}
+			elem = bson.E{Key: elem.Key, Value: append(append(bson.D(nil), setOnInsert...), created)}
+			added = true
+		}
+		composed = append(composed, elem)
+	}
+	if !added {
+		composed = append(composed, bson.E{Key: "$setOnInsert", Value: bson.D{created}})
+	}
+	return composed
+}
+
+// prepareReplace sets the defaults and timestamps of a replacement document and returns the document to write.
+// Unlike prepareCreate it keeps the _id unset, since the replacement takes the _id of the document it replaces,
+// and always sets the updated-at timestamp. For upserts, a zero created-at timestamp is set to the current time.
+// Otherwise a zero created-at timestamp is left out of the returned document, so the stored one is kept.
+func (c *Collect) prepareReplace(model interface{}, upsert bool) (interface{}, error) {
+	value := reflect.ValueOf(model)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return model, nil
+	}
+	value = value.Elem()
+
+	if err := applyDefaults(value, ""); err != nil {
+		return nil, err
+	}
+
+	policy := c.timestampPolicy()
+	if policy.Disabled {
+		return model, nil
+	}
+
+	now := policy.now()
+	if field, ok := fieldValueByBSONName(value, policy.UpdatedAt); ok && field.CanSet() {
+		field.Set(reflect.Zero(field.Type()))
+		setTime(field, now)
+	}
+
+	createdAt, ok := fieldValueByBSONName(value, policy.CreatedAt)
+	if !ok || !createdAt.IsZero() {
+		return model, nil
+	}
+	if upsert {
+		if createdAt.CanSet() {
+			setTime(createdAt, now)
+		}
+		return model, nil
+	}
+
+	doc, err := toDocument(model)
+	if err != nil {
+		return nil, err
+	}
+	replacement := make(bson.D, 0, len(doc))
+	for _, elem := range doc {
+		if elem.Key != policy.CreatedAt {
+			replacement = append(replacement, elem)
+		}
+	}
+	return replacement, nil
+}
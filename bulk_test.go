@@ -0,0 +1,118 @@
+package morm
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type replaced struct {
+	Model `bson:",inline"`
+	Name  string `bson:"name"`
+}
+
+// TestPrepareReplace tests the created-at timestamp of the document written by replacements
+func TestPrepareReplace(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &Collect{timestamps: &TimestampPolicy{Now: func() time.Time { return now }}}
+
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		upsert    bool
+		written   interface{}
+	}{
+		{name: "replace keeps the stored value", createdAt: time.Time{}, upsert: false, written: nil},
+		{name: "replace writes a set value", createdAt: created, upsert: false, written: primitive.NewDateTimeFromTime(created)},
+		{name: "upsert sets a zero value", createdAt: time.Time{}, upsert: true, written: primitive.NewDateTimeFromTime(now)},
+		{name: "upsert writes a set value", createdAt: created, upsert: true, written: primitive.NewDateTimeFromTime(created)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &replaced{Model: Model{CreatedAt: tt.createdAt}, Name: "a"}
+			replacement, err := c.prepareReplace(model, tt.upsert)
+			if err != nil {
+				t.Fatalf("Failed to prepare replacement: %v", err)
+			}
+
+			doc, err := toDocument(replacement)
+			if err != nil {
+				t.Fatalf("Failed to convert replacement: %v", err)
+			}
+			var written interface{}
+			for _, elem := range doc {
+				if elem.Key == "createdAt" {
+					written = elem.Value
+				}
+			}
+			if written != tt.written {
+				t.Fatalf("Expected createdAt %v, got %v", tt.written, written)
+			}
+			if !model.UpdatedAt.Equal(now) {
+				t.Fatalf("Expected updatedAt %v, got %v", now, model.UpdatedAt)
+			}
+		})
+	}
+}
+
+// TestComposeUpsert tests that upserts set the created-at timestamp on insert without changing the caller's update
+func TestComposeUpsert(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	created := bson.E{Key: "createdAt", Value: now}
+
+	tests := []struct {
+		name     string
+		disabled bool
+		update   interface{}
+		composed interface{}
+	}{
+		{
+			name:     "without $setOnInsert",
+			update:   bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "a"}}}},
+			composed: bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "a"}}}, {Key: "$setOnInsert", Value: bson.D{created}}},
+		},
+		{
+			name:     "with $setOnInsert",
+			update:   bson.D{{Key: "$setOnInsert", Value: bson.D{{Key: "name", Value: "a"}}}},
+			composed: bson.D{{Key: "$setOnInsert", Value: bson.D{{Key: "name", Value: "a"}, created}}},
+		},
+		{
+			name:     "writing createdAt",
+			update:   bson.D{{Key: "$set", Value: bson.D{{Key: "createdAt", Value: "then"}}}},
+			composed: bson.D{{Key: "$set", Value: bson.D{{Key: "createdAt", Value: "then"}}}},
+		},
+		{
+			name:     "pipeline",
+			update:   []interface{}{bson.D{{Key: "$unset", Value: "name"}}},
+			composed: []interface{}{bson.D{{Key: "$unset", Value: "name"}}},
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			update:   bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "a"}}}},
+			composed: bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "a"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Collect{timestamps: &TimestampPolicy{Disabled: tt.disabled, Now: func() time.Time { return now }}}
+
+			// Compose twice, as a bulk write does for an update reused across operations
+			original := fmt.Sprint(tt.update)
+			for i := 0; i < 2; i++ {
+				composed := c.composeUpsert(tt.update)
+				if !reflect.DeepEqual(composed, tt.composed) {
+					t.Fatalf("Expected %v, got %v", tt.composed, composed)
+				}
+			}
+			if fmt.Sprint(tt.update) != original {
+				t.Fatalf("Expected the update to be left unchanged, got %v", tt.update)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package morm
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Hint sets the index the query uses, as an index name or an index specification document.
+//
+// Example:
+//
+//	qb.Find(bson.M{"email": email}).Hint("email_1").Exec()
+func (qb *CollectQueryBuilder) Hint(hint interface{}) *CollectQueryBuilder {
+	qb.hint = hint
+	return qb
+}
+
+// Collation sets the language-specific rules used to compare strings in the query.
+//
+// Example:
+//
+//	qb.Find(bson.M{"name": "ada"}).Collation(&options.Collation{Locale: "en", Strength: 2}).Exec()
+func (qb *CollectQueryBuilder) Collation(collation *options.Collation) *CollectQueryBuilder {
+	qb.collation = collation
+	return qb
+}
+
+// Count counts the documents matching the query.
+// It reuses the builder's filter, Where conditions, skip, limit, hint and collation, and runs the HookCount hooks,
+// so pre hooks scoping queries apply to counts as well.
+//
+// Parameters:
+//   - ctx: Optional context.Context for the count. If not provided, the builder's context is used.
+//
+// Returns:
+//   - int64: The number of matching documents.
+//   - error: An error if the count failed.
+//
+// Example:
+//
+//	n, err := qb.Find(bson.M{"active": true}).Count()
+func (qb *CollectQueryBuilder) Count(ctx ...context.Context) (int64, error) {
+	return count(qb.getContext(ctx...), qb)
+}
+
+// count runs the count hooks and counts the documents matching the query.
+func count(ctx context.Context, qb *CollectQueryBuilder) (int64, error) {
+	filter, err := qb.buildFilter()
+	if err != nil {
+		return 0, err
+	}
+
+	op := &Operation{Name: HookCount, Filter: filter}
+	if err := qb.c.runHooks(ctx, true, op); err != nil {
+		return 0, err
+	}
+
+	options := options.Count()
+	if qb.skip != 0 {
+		options.SetSkip(qb.skip)
+	}
+	if qb.limit != 0 {
+		options.SetLimit(qb.limit)
+	}
+	if qb.hint != nil {
+		options.SetHint(qb.hint)
+	}
+	if qb.collation != nil {
+		options.SetCollation(qb.collation)
+	}
+
+	n, err := qb.c.collection.CountDocuments(ctx, op.Filter, options)
+	if err != nil {
+		return 0, err
+	}
+
+	op.Result = n
+	if err := qb.c.runHooks(ctx, false, op); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// EstimatedCount returns an estimate of the number of documents in the collection from its metadata.
+// It is much faster than Count on large collections but ignores the filter, and runs the HookEstimatedCount hooks.
+//
+// Example:
+//
+//	n, err := qb.EstimatedCount()
+func (qb *CollectQueryBuilder) EstimatedCount(ctx ...context.Context) (int64, error) {
+	backgroundContext := qb.getContext(ctx...)
+
+	op := &Operation{Name: HookEstimatedCount}
+	if err := qb.c.runHooks(backgroundContext, true, op); err != nil {
+		return 0, err
+	}
+
+	n, err := qb.c.collection.EstimatedDocumentCount(backgroundContext)
+	if err != nil {
+		return 0, err
+	}
+
+	op.Result = n
+	if err := qb.c.runHooks(backgroundContext, false, op); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// DocumentExists reports whether a document matches the filter combined with the builder's conditions.
+// It stops counting at the first match and runs the HookCount hooks.
+// It is named DocumentExists because Exists adds an $exists condition to the field selected with Where.
+//
+// Example:
+//
+//	taken, err := qb.DocumentExists(bson.M{"email": email})
+func (qb *CollectQueryBuilder) DocumentExists(filter interface{}, ctx ...context.Context) (bool, error) {
+	existsQuery := *qb
+	existsQuery.skip = 0
+	existsQuery.limit = 1
+	if filter != nil {
+		existsQuery.filter = filter
+	}
+
+	n, err := count(qb.getContext(ctx...), &existsQuery)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Count counts the documents matching the typed find query. See CollectQueryBuilder.Count.
+func (q *FindQuery[T]) Count(ctx ...context.Context) (int64, error) {
+	return q.qb.Count(ctx...)
+}
+
+// Hint sets the index the typed find query uses.
+func (q *FindQuery[T]) Hint(hint interface{}) *FindQuery[T] {
+	q.qb.Hint(hint)
+	return q
+}
+
+// Collation sets the collation of the typed find query.
+func (q *FindQuery[T]) Collation(collation *options.Collation) *FindQuery[T] {
+	q.qb.Collation(collation)
+	return q
+}
+
+// EstimatedCount returns an estimate of the number of documents in the collection.
+func (tc *TypedCollection[T]) EstimatedCount(ctx ...context.Context) (int64, error) {
+	return tc.Query().EstimatedCount(ctx...)
+}
+
+// DocumentExists reports whether a document matches the filter. See CollectQueryBuilder.DocumentExists.
+//
+// Example:
+//
+//	taken, err := users.DocumentExists(bson.M{"email": email})
+func (tc *TypedCollection[T]) DocumentExists(filter interface{}, ctx ...context.Context) (bool, error) {
+	return tc.Query().DocumentExists(filter, ctx...)
+}
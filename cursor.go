@@ -0,0 +1,168 @@
+package morm
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Cursor streams the documents of a find query one at a time instead of buffering the whole result.
+// It honours the projection, sort, skip, limit, batch size and populated fields of the query.
+//
+// Pre find hooks run when the cursor is opened and AfterFind model hooks run on every decoded document.
+// Post find hooks do not run, as the results are never collected.
+//
+// Example:
+//
+//	cursor, err := qb.Find(bson.M{"active": true}).BatchSize(1000).Cursor(ctx)
+//	if err != nil {
+//	  // Handle error
+//	}
+//	defer cursor.Close(ctx)
+//
+//	for cursor.Next(ctx) {
+//	  var user User
+//	  if err := cursor.Decode(&user); err != nil {
+//	    // Handle error
+//	  }
+//	}
+//	if err := cursor.Err(); err != nil {
+//	  // Handle error
+//	}
+type Cursor struct {
+	qb     *CollectQueryBuilder
+	ctx    context.Context
+	cursor *mongo.Cursor
+
+	// populated documents of the current batch, when dynamic references are populated
+	buffered bool
+	batch    []bson.Raw
+	current  bson.Raw
+	err      error
+}
+
+// TypedCursor streams the documents of a typed find query, decoding them into *T.
+type TypedCursor[T any] struct {
+	*Cursor
+}
+
+// Cursor opens a cursor on the find query instead of executing it.
+//
+// Parameters:
+//   - ctx: Optional context.Context used to open the cursor and run hooks. If not provided, the builder's context is used.
+//
+// Returns:
+//   - *Cursor: The cursor, which must be closed when done.
+//   - error: An error if the query could not be run.
+func (qb *CollectQueryBuilder) Cursor(ctx ...context.Context) (*Cursor, error) {
+	backgroundContext := qb.getContext(ctx...)
+
+	cursor, _, err := openFind(backgroundContext, qb)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cursor{
+		qb:       qb,
+		ctx:      backgroundContext,
+		cursor:   cursor,
+		buffered: len(qb.popPaths) > 0,
+	}, nil
+}
+
+// Next advances the cursor to the next document, fetching the next batch from the server when needed.
+// It returns false when the cursor is exhausted or an error occurred; check Err to tell them apart.
+func (c *Cursor) Next(ctx context.Context) bool {
+	if c.err != nil {
+		return false
+	}
+	if !c.buffered {
+		return c.cursor.Next(ctx)
+	}
+
+	if len(c.batch) == 0 && !c.nextBatch(ctx) {
+		return false
+	}
+	c.current, c.batch = c.batch[0], c.batch[1:]
+	return true
+}
+
+// nextBatch reads the documents of the next server batch and populates their dynamic references,
+// so each referenced collection is queried once per batch rather than once per document.
+func (c *Cursor) nextBatch(ctx context.Context) bool {
+	if !c.cursor.Next(ctx) {
+		return false
+	}
+
+	docs := []bson.Raw{append(bson.Raw(nil), c.cursor.Current...)}
+	for c.cursor.RemainingBatchLength() > 0 && c.cursor.Next(ctx) {
+		docs = append(docs, append(bson.Raw(nil), c.cursor.Current...))
+	}
+
+	docs, err := c.qb.populateRefPaths(ctx, c.qb.c.modelType.Elem(), c.qb.popPaths, docs)
+	if err != nil {
+		c.err = err
+		return false
+	}
+
+	c.batch = docs
+	return len(c.batch) > 0
+}
+
+// Decode decodes the current document into v and runs its AfterFind hook.
+func (c *Cursor) Decode(v interface{}) error {
+	if c.buffered {
+		if err := bson.Unmarshal(c.current, v); err != nil {
+			return err
+		}
+	} else if err := c.cursor.Decode(v); err != nil {
+		return err
+	}
+
+	return callModelHook(c.ctx, v, "AfterFind")
+}
+
+// Err returns the last error of the cursor, if any.
+func (c *Cursor) Err() error {
+	if c.err != nil {
+		return c.err
+	}
+	return c.cursor.Err()
+}
+
+// Close closes the cursor, releasing its server resources.
+func (c *Cursor) Close(ctx context.Context) error {
+	return c.cursor.Close(ctx)
+}
+
+// Cursor opens a typed cursor on the find query instead of executing it.
+//
+// Example:
+//
+//	cursor, err := users.Find(bson.M{}).BatchSize(1000).Cursor(ctx)
+//	if err != nil {
+//	  // Handle error
+//	}
+//	defer cursor.Close(ctx)
+//
+//	for cursor.Next(ctx) {
+//	  user, err := cursor.Decode()
+//	  ...
+//	}
+func (q *FindQuery[T]) Cursor(ctx ...context.Context) (*TypedCursor[T], error) {
+	cursor, err := q.qb.Cursor(ctx...)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedCursor[T]{Cursor: cursor}, nil
+}
+
+// Decode decodes the current document into a new *T and runs its AfterFind hook.
+func (c *TypedCursor[T]) Decode() (*T, error) {
+	result := new(T)
+	if err := c.Cursor.Decode(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
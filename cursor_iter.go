@@ -0,0 +1,46 @@
+//go:build go1.23
+
+package morm
+
+import (
+	"context"
+	"iter"
+)
+
+// All returns an iterator over the documents of the typed find query, for use with range.
+// The cursor is closed when the loop ends. An error stops the iteration after it is yielded.
+//
+// Example:
+//
+//	for user, err := range users.Find(bson.M{}).BatchSize(1000).All(ctx) {
+//	  if err != nil {
+//	    // Handle error
+//	    break
+//	  }
+//	  export(user)
+//	}
+func (q *FindQuery[T]) All(ctx context.Context) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		cursor, err := q.Cursor(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			result, err := cursor.Decode()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(result, nil) {
+				return
+			}
+		}
+
+		if err := cursor.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package morm
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyDefaults sets the zero fields of a struct that have a "default=" morm rule to the rule's value,
+// recursing into nested and inline structs.
+// Defaults are parsed into the field type, which may be a string, bool, number, time.Duration or a pointer to one.
+func applyDefaults(value reflect.Value, prefix string) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		structField := valueType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name, inline, skip := bsonFieldName(structField)
+		if skip {
+			continue
+		}
+
+		path := prefix
+		if !inline {
+			path = joinPath(prefix, name)
+		}
+
+		field := value.Field(i)
+		for _, r := range parseRules(structField.Tag.Get("morm")) {
+			if r.name != "default" || !field.IsZero() || !field.CanSet() {
+				continue
+			}
+			if err := setDefault(field, r.param); err != nil {
+				return fmt.Errorf("default of field %q: %w", path, err)
+			}
+		}
+
+		nested := field
+		if nested.Kind() == reflect.Ptr {
+			if nested.IsNil() {
+				continue
+			}
+			nested = nested.Elem()
+		}
+		if nested.Kind() == reflect.Struct && nested.Type() != timeType {
+			if err := applyDefaults(nested, path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setDefault parses a default value into the field.
+func setDefault(field reflect.Value, param string) error {
+	if field.Kind() == reflect.Ptr {
+		target := reflect.New(field.Type().Elem())
+		if err := setDefault(target.Elem(), param); err != nil {
+			return err
+		}
+		field.Set(target)
+		return nil
+	}
+
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(param)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(param)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(param)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(param, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(param, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(param, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package morm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type defaulted struct {
+	Status   string        `bson:"status" morm:"default=open"`
+	Priority int           `bson:"priority" morm:"default=3"`
+	Ratio    float64       `bson:"ratio" morm:"default=0.5"`
+	Timeout  time.Duration `bson:"timeout" morm:"default=1m30s"`
+	Notify   *bool         `bson:"notify" morm:"default=true"`
+	Address  struct {
+		City string `bson:"city" morm:"default=Lagos"`
+	} `bson:"address"`
+}
+
+// TestApplyDefaults tests that defaults are parsed into zero fields, nested fields included, and set fields are kept
+func TestApplyDefaults(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name     string
+		model    defaulted
+		expected defaulted
+	}{
+		{
+			name:  "zero fields",
+			model: defaulted{},
+			expected: defaulted{
+				Status:   "open",
+				Priority: 3,
+				Ratio:    0.5,
+				Timeout:  90 * time.Second,
+				Notify:   &yes,
+			},
+		},
+		{
+			name:  "set fields",
+			model: defaulted{Status: "closed", Priority: 1, Ratio: 1, Timeout: time.Second, Notify: &no},
+			expected: defaulted{
+				Status:   "closed",
+				Priority: 1,
+				Ratio:    1,
+				Timeout:  time.Second,
+				Notify:   &no,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expected.Address.City = "Lagos"
+
+			model := tt.model
+			if err := applyDefaults(reflect.ValueOf(&model).Elem(), ""); err != nil {
+				t.Fatalf("Failed to apply defaults: %v", err)
+			}
+			if !reflect.DeepEqual(model, tt.expected) {
+				t.Fatalf("Expected %+v, got %+v", tt.expected, model)
+			}
+		})
+	}
+}
+
+// TestApplyDefaultsErrors tests that invalid defaults are reported with the field path
+func TestApplyDefaultsErrors(t *testing.T) {
+	model := struct {
+		Nested struct {
+			Count int `bson:"count" morm:"default=many"`
+		} `bson:"nested"`
+	}{}
+
+	err := applyDefaults(reflect.ValueOf(&model).Elem(), "")
+	if err == nil || err.Error() != `default of field "nested.count": strconv.ParseInt: parsing "many": invalid syntax` {
+		t.Fatalf("Expected an error naming the field, got %v", err)
+	}
+}
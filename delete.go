@@ -3,6 +3,7 @@ package morm
 import "context"
 
 // Delete deletes a single document from the specified collection based on the provided filter.
+// Pre and post "deleteOne" hooks run around the delete, and the BeforeDelete model hook before it.
 //
 // Parameters:
 //   - filter: The filter to match documents for deletion.
@@ -12,20 +13,24 @@ import "context"
 //   - error: An error if any occurred during the delete operation.
 func (qb *CollectQueryBuilder) Delete(filter interface{}, ctx ...context.Context) error {
 	collection := qb.c.collection
-	var backgroundContext = context.Background()
-	if len(ctx) > 0 {
-		backgroundContext = ctx[0]
+	backgroundContext := qb.getContext(ctx...)
+
+	op := &Operation{Name: HookDeleteOne, Filter: filter}
+	if err := qb.beforeDelete(backgroundContext, op); err != nil {
+		return err
 	}
 
-	_, err := collection.DeleteOne(backgroundContext, filter)
+	result, err := collection.DeleteOne(backgroundContext, op.Filter)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	op.Result = result
+	return qb.afterDelete(backgroundContext, op, nil)
 }
 
 // DeleteMany deletes multiple documents from the specified collection based on the provided filter.
+// Pre and post "deleteMany" hooks run around the delete, and the BeforeDelete model hook before it.
 //
 // Parameters:
 //   - filter: The filter to match documents for deletion.
@@ -36,15 +41,49 @@ func (qb *CollectQueryBuilder) Delete(filter interface{}, ctx ...context.Context
 //   - error: An error if any occurred during the delete operation.
 func (qb *CollectQueryBuilder) DeleteMany(filter interface{}, ctx ...context.Context) (int64, error) {
 	collection := qb.c.collection
-	var backgroundContext = context.Background()
-	if len(ctx) > 0 {
-		backgroundContext = ctx[0]
+	backgroundContext := qb.getContext(ctx...)
+
+	op := &Operation{Name: HookDeleteMany, Filter: filter}
+	if err := qb.beforeDelete(backgroundContext, op); err != nil {
+		return 0, err
 	}
 
-	result, err := collection.DeleteMany(backgroundContext, filter)
+	result, err := collection.DeleteMany(backgroundContext, op.Filter)
 	if err != nil {
 		return 0, err
 	}
 
+	op.Result = result
+	if err := qb.afterDelete(backgroundContext, op, nil); err != nil {
+		return result.DeletedCount, err
+	}
+
 	return result.DeletedCount, nil
 }
+
+// beforeDelete merges the builder's Where conditions into the filter of a delete operation and runs its pre hooks
+// and the BeforeDelete model hook.
+func (qb *CollectQueryBuilder) beforeDelete(ctx context.Context, op *Operation) error {
+	filter, err := qb.writeFilter(op.Filter)
+	if err != nil {
+		return err
+	}
+	op.Filter = filter
+
+	if err := qb.c.runHooks(ctx, true, op); err != nil {
+		return err
+	}
+	if h, ok := qb.c.newModel().(BeforeDeleter); ok {
+		return h.BeforeDelete(ctx, op.Filter)
+	}
+	return nil
+}
+
+// afterDelete runs the AfterDelete model hook on the deleted document, if the operation read it,
+// and the post hooks of a delete operation.
+func (qb *CollectQueryBuilder) afterDelete(ctx context.Context, op *Operation, deleted interface{}) error {
+	if err := callModelHook(ctx, deleted, "AfterDelete"); err != nil {
+		return err
+	}
+	return qb.c.runHooks(ctx, false, op)
+}
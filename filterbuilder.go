@@ -0,0 +1,226 @@
+package morm
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// errNoWhereField is recorded when a condition is added before Where selected a field.
+var errNoWhereField = errors.New("condition requires a field; call Where first")
+
+// Where selects the field the following conditions apply to, Mongoose style.
+// If a value is provided, it also adds an equality condition.
+// Conditions are merged with the filter passed to Find or FindOne, and with the filter of UpdateOne, Update,
+// Delete, DeleteMany, FindOneAndUpdate, FindOneAndRemove and Bulk operations run on the builder.
+// Writes only use the filter passed to them, never the one set by an earlier Find or FindOne.
+// Exec returns an ErrUnknownField error if a field does not exist in the model's bson tags,
+// including the fields of the filters passed to Or, Nor and And.
+//
+// Example:
+//
+//	qb.Find(bson.M{"active": true}).Where("age").Gte(18).Lt(65).Where("role").In("admin", "editor").Exec()
+//
+// This method is useful for building filters without writing nested bson documents by hand.
+func (qb *CollectQueryBuilder) Where(field string, value ...interface{}) *CollectQueryBuilder {
+	qb.wherePath = field
+	if len(value) > 0 {
+		qb.Eq(value[0])
+	}
+	return qb
+}
+
+// Eq adds a condition that the selected field equals value.
+func (qb *CollectQueryBuilder) Eq(value interface{}) *CollectQueryBuilder {
+	return qb.addCondition("$eq", value)
+}
+
+// Ne adds a condition that the selected field does not equal value.
+func (qb *CollectQueryBuilder) Ne(value interface{}) *CollectQueryBuilder {
+	return qb.addCondition("$ne", value)
+}
+
+// Gt adds a condition that the selected field is greater than value.
+func (qb *CollectQueryBuilder) Gt(value interface{}) *CollectQueryBuilder {
+	return qb.addCondition("$gt", value)
+}
+
+// Gte adds a condition that the selected field is greater than or equal to value.
+func (qb *CollectQueryBuilder) Gte(value interface{}) *CollectQueryBuilder {
+	return qb.addCondition("$gte", value)
+}
+
+// Lt adds a condition that the selected field is less than value.
+func (qb *CollectQueryBuilder) Lt(value interface{}) *CollectQueryBuilder {
+	return qb.addCondition("$lt", value)
+}
+
+// Lte adds a condition that the selected field is less than or equal to value.
+func (qb *CollectQueryBuilder) Lte(value interface{}) *CollectQueryBuilder {
+	return qb.addCondition("$lte", value)
+}
+
+// In adds a condition that the selected field equals one of the values.
+func (qb *CollectQueryBuilder) In(values ...interface{}) *CollectQueryBuilder {
+	return qb.addCondition("$in", bson.A(values))
+}
+
+// Nin adds a condition that the selected field equals none of the values.
+func (qb *CollectQueryBuilder) Nin(values ...interface{}) *CollectQueryBuilder {
+	return qb.addCondition("$nin", bson.A(values))
+}
+
+// Regex adds a condition that the selected field matches the regular expression.
+// Options such as "i" for case insensitive matching can be provided as an optional argument.
+func (qb *CollectQueryBuilder) Regex(pattern string, options ...string) *CollectQueryBuilder {
+	regex := primitive.Regex{Pattern: pattern}
+	if len(options) > 0 {
+		regex.Options = options[0]
+	}
+	return qb.addCondition("$regex", regex)
+}
+
+// Exists adds a condition on whether the selected field is present in the document.
+func (qb *CollectQueryBuilder) Exists(exists bool) *CollectQueryBuilder {
+	return qb.addCondition("$exists", exists)
+}
+
+// ElemMatch adds a condition that at least one element of the selected array field matches the filter.
+func (qb *CollectQueryBuilder) ElemMatch(filter interface{}) *CollectQueryBuilder {
+	return qb.addCondition("$elemMatch", filter)
+}
+
+// Size adds a condition that the selected array field has exactly n elements.
+func (qb *CollectQueryBuilder) Size(n int) *CollectQueryBuilder {
+	return qb.addCondition("$size", n)
+}
+
+// Or adds a condition that at least one of the filters matches.
+//
+// Example:
+//
+//	qb.Find().Or(bson.M{"status": "draft"}, bson.M{"author": id}).Exec()
+func (qb *CollectQueryBuilder) Or(filters ...interface{}) *CollectQueryBuilder {
+	qb.whereClauses = append(qb.whereClauses, bson.D{{Key: "$or", Value: bson.A(filters)}})
+	return qb
+}
+
+// Nor adds a condition that none of the filters match.
+func (qb *CollectQueryBuilder) Nor(filters ...interface{}) *CollectQueryBuilder {
+	qb.whereClauses = append(qb.whereClauses, bson.D{{Key: "$nor", Value: bson.A(filters)}})
+	return qb
+}
+
+// And adds a condition that all of the filters match.
+func (qb *CollectQueryBuilder) And(filters ...interface{}) *CollectQueryBuilder {
+	qb.whereClauses = append(qb.whereClauses, bson.D{{Key: "$and", Value: bson.A(filters)}})
+	return qb
+}
+
+// addCondition adds an operator condition on the field selected with Where.
+// Conditions on the same field are combined into a single operator document.
+func (qb *CollectQueryBuilder) addCondition(operator string, value interface{}) *CollectQueryBuilder {
+	if qb.wherePath == "" {
+		qb.whereErr = errNoWhereField
+		return qb
+	}
+
+	for i, elem := range qb.where {
+		if elem.Key == qb.wherePath {
+			qb.where[i].Value = append(elem.Value.(bson.D), bson.E{Key: operator, Value: value})
+			return qb
+		}
+	}
+
+	qb.where = append(qb.where, bson.E{Key: qb.wherePath, Value: bson.D{{Key: operator, Value: value}}})
+	return qb
+}
+
+// buildFilter merges the raw filter with the conditions added through Where, Or, Nor and And.
+//
+// Returns:
+//   - interface{}: The raw filter when no conditions were added, the conditions when no raw filter was set,
+//     or an $and of all of them. An empty document when there is nothing to filter on.
+//   - error: An ErrUnknownField error if a Where, Or, Nor or And field does not exist in the model,
+//     or a misuse of the chain.
+func (qb *CollectQueryBuilder) buildFilter() (interface{}, error) {
+	if qb.whereErr != nil {
+		return nil, qb.whereErr
+	}
+	for _, elem := range qb.where {
+		if err := checkField(qb.c.modelType, elem.Key); err != nil {
+			return nil, err
+		}
+	}
+	for _, clause := range qb.whereClauses {
+		if err := checkFilterFields(qb.c.modelType, clause); err != nil {
+			return nil, err
+		}
+	}
+
+	var clauses bson.A
+	if qb.filter != nil {
+		clauses = append(clauses, qb.filter)
+	}
+	if len(qb.where) > 0 {
+		clauses = append(clauses, qb.where)
+	}
+	for _, clause := range qb.whereClauses {
+		clauses = append(clauses, clause)
+	}
+
+	switch len(clauses) {
+	case 0:
+		return bson.D{}, nil
+	case 1:
+		return clauses[0], nil
+	}
+	return bson.D{{Key: "$and", Value: clauses}}, nil
+}
+
+// writeFilter returns the filter of a write operation: the filter passed to it combined with the builder's
+// Where conditions. The filter set by Find or FindOne is not used, so a reused builder does not scope writes to it.
+// A nil filter without conditions is returned as is, so the write is rejected instead of matching every document.
+func (qb *CollectQueryBuilder) writeFilter(filter interface{}) (interface{}, error) {
+	if filter == nil && qb.whereErr == nil && len(qb.where) == 0 && len(qb.whereClauses) == 0 {
+		return nil, nil
+	}
+	q := *qb
+	q.filter = filter
+	return q.buildFilter()
+}
+
+// checkFilterFields checks the field names of a filter, descending into the filters of $and, $or and $nor.
+// Other operators, such as $expr or $text, are not checked.
+func checkFilterFields(modelType reflect.Type, filter interface{}) error {
+	doc, err := toDocument(filter)
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range doc {
+		switch elem.Key {
+		case "$and", "$or", "$nor":
+			filters := reflect.ValueOf(elem.Value)
+			if filters.Kind() != reflect.Slice && filters.Kind() != reflect.Array {
+				continue
+			}
+			for i := 0; i < filters.Len(); i++ {
+				if err := checkFilterFields(modelType, filters.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+		default:
+			if strings.HasPrefix(elem.Key, "$") {
+				continue
+			}
+			if err := checkField(modelType, elem.Key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package morm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type filterModel struct {
+	Model  `bson:",inline"`
+	Name   string `bson:"name"`
+	Status string `bson:"status"`
+}
+
+func newFilterBuilder() *CollectQueryBuilder {
+	return &CollectQueryBuilder{c: &Collect{modelType: reflect.TypeOf(&filterModel{})}}
+}
+
+// TestBuildFilter tests the filters compiled from Where conditions, clauses and the Find filter
+func TestBuildFilter(t *testing.T) {
+	cond := func(field, operator string, value interface{}) bson.E {
+		return bson.E{Key: field, Value: bson.D{{Key: operator, Value: value}}}
+	}
+
+	tests := []struct {
+		name     string
+		qb       *CollectQueryBuilder
+		expected interface{}
+	}{
+		{name: "empty", qb: newFilterBuilder(), expected: bson.D{}},
+		{name: "find filter", qb: newFilterBuilder().Find(bson.M{"name": "a"}), expected: bson.M{"name": "a"}},
+		{name: "eq", qb: newFilterBuilder().Where("name", "a"), expected: bson.D{cond("name", "$eq", "a")}},
+		{name: "ne", qb: newFilterBuilder().Where("name").Ne("a"), expected: bson.D{cond("name", "$ne", "a")}},
+		{name: "nin", qb: newFilterBuilder().Where("status").Nin("a", "b"), expected: bson.D{cond("status", "$nin", bson.A{"a", "b"})}},
+		{
+			name:     "regex",
+			qb:       newFilterBuilder().Where("name").Regex("^a", "i"),
+			expected: bson.D{cond("name", "$regex", primitive.Regex{Pattern: "^a", Options: "i"})},
+		},
+		{name: "exists", qb: newFilterBuilder().Where("status").Exists(false), expected: bson.D{cond("status", "$exists", false)}},
+		{
+			name:     "range on one field",
+			qb:       newFilterBuilder().Where("createdAt").Gt(1).Lte(2),
+			expected: bson.D{{Key: "createdAt", Value: bson.D{{Key: "$gt", Value: 1}, {Key: "$lte", Value: 2}}}},
+		},
+		{
+			name:     "several fields",
+			qb:       newFilterBuilder().Where("name").Gte("a").Where("status").Lt("z"),
+			expected: bson.D{cond("name", "$gte", "a"), cond("status", "$lt", "z")},
+		},
+		{
+			name: "find filter, where and clause",
+			qb:   newFilterBuilder().Find(bson.M{"name": "a"}).Where("status", "open").Nor(bson.M{"name": "b"}),
+			expected: bson.D{{Key: "$and", Value: bson.A{
+				bson.M{"name": "a"},
+				bson.D{cond("status", "$eq", "open")},
+				bson.D{{Key: "$nor", Value: bson.A{bson.M{"name": "b"}}}},
+			}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := tt.qb.buildFilter()
+			if err != nil {
+				t.Fatalf("Failed to build filter: %v", err)
+			}
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, filter)
+			}
+		})
+	}
+}
+
+// TestWriteFilter tests that writes combine their own filter with the Where conditions, ignoring a Find filter
+func TestWriteFilter(t *testing.T) {
+	where := bson.D{{Key: "status", Value: bson.D{{Key: "$eq", Value: "open"}}}}
+
+	tests := []struct {
+		name     string
+		qb       *CollectQueryBuilder
+		filter   interface{}
+		expected interface{}
+	}{
+		{name: "nothing", qb: newFilterBuilder(), filter: nil, expected: nil},
+		{name: "stale find filter", qb: newFilterBuilder().Find(bson.M{"name": "a"}), filter: nil, expected: nil},
+		{name: "own filter", qb: newFilterBuilder().Find(bson.M{"name": "a"}), filter: bson.M{"name": "b"}, expected: bson.M{"name": "b"}},
+		{name: "where only", qb: newFilterBuilder().Find(bson.M{"name": "a"}).Where("status", "open"), filter: nil, expected: where},
+		{
+			name:     "filter and where",
+			qb:       newFilterBuilder().Where("status", "open"),
+			filter:   bson.M{"name": "b"},
+			expected: bson.D{{Key: "$and", Value: bson.A{bson.M{"name": "b"}, where}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := tt.qb.writeFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("Failed to build filter: %v", err)
+			}
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, filter)
+			}
+		})
+	}
+}
+
+// TestBuildFilterFields tests that the fields of Where, Or, Nor and And conditions are checked against the model
+func TestBuildFilterFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		qb      *CollectQueryBuilder
+		unknown bool
+	}{
+		{name: "known where", qb: newFilterBuilder().Where("name", "a"), unknown: false},
+		{name: "unknown where", qb: newFilterBuilder().Where("age", 1), unknown: true},
+		{name: "known or", qb: newFilterBuilder().Or(bson.M{"name": "a"}, bson.D{{Key: "_id", Value: 1}}), unknown: false},
+		{name: "unknown or", qb: newFilterBuilder().Or(bson.M{"name": "a"}, bson.M{"age": 1}), unknown: true},
+		{name: "unknown nor", qb: newFilterBuilder().Nor(bson.M{"age": 1}), unknown: true},
+		{name: "unknown nested and", qb: newFilterBuilder().And(bson.M{"$or": bson.A{bson.M{"age": 1}}}), unknown: true},
+		{name: "operator", qb: newFilterBuilder().And(bson.M{"$expr": bson.M{"$gt": bson.A{"$age", 1}}}), unknown: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.qb.buildFilter()
+			if unknown := errors.Is(err, ErrUnknownField); unknown != tt.unknown || (!tt.unknown && err != nil) {
+				t.Fatalf("Expected unknown field %v, got %v", tt.unknown, err)
+			}
+		})
+	}
+}
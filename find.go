@@ -4,10 +4,8 @@ import (
 	"context"
 	"errors"
 	"reflect"
-	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -24,8 +22,88 @@ func (qb *CollectQueryBuilder) Find(filter ...interface{}) *CollectQueryBuilder
 
 // find performs the MongoDB find operation based on the CollectQueryBuilder configuration.
 // It supports projection, sorting, skipping, and limiting of results.
-// The results are decoded into the provided result interface.
-func find(qb *CollectQueryBuilder, result interface{}) (interface{}, error) {
+// When fields are populated, the query and the lookups run as a single aggregation.
+// Each document is decoded into a fresh value returned by newResult.
+// The context is used for the query, the cursor iteration and any populate lookups.
+func find(ctx context.Context, qb *CollectQueryBuilder, newResult func() interface{}) ([]interface{}, error) {
+	cursor, op, err := openFind(ctx, qb)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []interface{}
+
+	decode := func(unmarshal func(interface{}) error) error {
+		result := newResult()
+		if err := unmarshal(result); err != nil {
+			return err
+		}
+
+		if err := callModelHook(ctx, result, "AfterFind"); err != nil {
+			return err
+		}
+		results = append(results, result)
+		return nil
+	}
+
+	if len(qb.popPaths) > 0 {
+		// Read all documents first, so dynamic references are populated with one query per collection
+		var docs []bson.Raw
+		for cursor.Next(ctx) {
+			docs = append(docs, append(bson.Raw(nil), cursor.Current...))
+		}
+		if err := cursor.Err(); err != nil {
+			return nil, err
+		}
+
+		docs, err = qb.populateRefPaths(ctx, qb.c.modelType.Elem(), qb.popPaths, docs)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range docs {
+			doc := doc
+			if err := decode(func(result interface{}) error { return bson.Unmarshal(doc, result) }); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for cursor.Next(ctx) {
+			if err := decode(cursor.Decode); err != nil {
+				return nil, err
+			}
+		}
+		if err := cursor.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	op.Result = results
+	if err := qb.c.runHooks(ctx, false, op); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// openFind runs the pre find hooks and opens the cursor of a find query.
+// When fields are populated, the query and the lookups run as a single aggregation.
+func openFind(ctx context.Context, qb *CollectQueryBuilder) (*mongo.Cursor, *Operation, error) {
+	filter, err := qb.buildFilter()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	op := &Operation{Name: HookFind, Filter: filter}
+	if err := qb.c.runHooks(ctx, true, op); err != nil {
+		return nil, nil, err
+	}
+
+	if len(qb.popPaths) > 0 {
+		cursor, err := qb.populate(ctx, op.Filter)
+		return cursor, op, err
+	}
+
 	options := options.Find()
 	if qb.projection != nil {
 		options.SetProjection(qb.projection)
@@ -39,44 +117,57 @@ func find(qb *CollectQueryBuilder, result interface{}) (interface{}, error) {
 	if qb.limit != 0 {
 		options.SetLimit(qb.limit)
 	}
+	if qb.batchSize != 0 {
+		options.SetBatchSize(qb.batchSize)
+	}
+	if qb.hint != nil {
+		options.SetHint(qb.hint)
+	}
+	if qb.collation != nil {
+		options.SetCollation(qb.collation)
+	}
 
-	cursor, err := qb.c.collection.Find(context.Background(), qb.filter, options)
+	cursor, err := qb.c.collection.Find(ctx, op.Filter, options)
+	return cursor, op, err
+}
+
+// populate runs a find query with populated fields as a single aggregation, so the related documents of all
+// matched documents are looked up in one round-trip instead of one per document.
+// The sort, skip and limit of the builder are applied before the lookups and the projection after them.
+func (qb *CollectQueryBuilder) populate(ctx context.Context, filter interface{}) (*mongo.Cursor, error) {
+	pipelineStages := []bson.D{{{Key: "$match", Value: filter}}}
+	if qb.sort != nil {
+		pipelineStages = append(pipelineStages, bson.D{{Key: "$sort", Value: qb.sort}})
+	}
+	if qb.skip != 0 {
+		pipelineStages = append(pipelineStages, bson.D{{Key: "$skip", Value: qb.skip}})
+	}
+	if qb.limit != 0 {
+		pipelineStages = append(pipelineStages, bson.D{{Key: "$limit", Value: qb.limit}})
+	}
+
+	lookupStages, err := qb.populateStages(qb.c.modelType.Elem(), qb.popPaths)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
-
-	var results []interface{}
-
-	for cursor.Next(context.Background()) {
-		if qb.popFields != nil {
-			result := reflect.New(qb.c.modelType.Elem()).Interface()
-			err := cursor.Decode(result)
-			if err != nil {
-				return nil, err
-			}
+	pipelineStages = append(pipelineStages, lookupStages...)
 
-			id := reflect.ValueOf(result).Elem().FieldByName("ID")
-			popRes, err := qb.virtual(qb.popFields, result, bson.M{"_id": id.Interface().(primitive.ObjectID)})
-			if err != nil {
-				return nil, err
-			}
-			results = append(results, popRes)
-		} else {
-			result := reflect.New(qb.c.modelType.Elem()).Interface()
-			err := cursor.Decode(result)
-			if err != nil {
-				return nil, err
-			}
-			results = append(results, result)
-		}
+	if qb.projection != nil {
+		pipelineStages = append(pipelineStages, bson.D{{Key: "$project", Value: qb.projection}})
 	}
 
-	if err := cursor.Err(); err != nil {
-		return nil, err
+	options := options.Aggregate()
+	if qb.batchSize != 0 {
+		options.SetBatchSize(qb.batchSize)
+	}
+	if qb.hint != nil {
+		options.SetHint(qb.hint)
+	}
+	if qb.collation != nil {
+		options.SetCollation(qb.collation)
 	}
 
-	return results, nil
+	return qb.c.collection.Aggregate(ctx, pipelineStages, options)
 }
 
 // FindOne sets the filter for the MongoDB query in the CollectQueryBuilder and specifies it's a findone operation.
@@ -90,7 +181,7 @@ func (qb *CollectQueryBuilder) FindOne(filter interface{}) *CollectQueryBuilder
 // findone performs the MongoDB findone operation based on the CollectQueryBuilder configuration.
 // It supports projection, sorting, skipping, and populate fields.
 // The result is decoded into the provided result interface.
-func findone(qb *CollectQueryBuilder, result interface{}) (interface{}, error) {
+func findone(ctx context.Context, qb *CollectQueryBuilder, result interface{}) (interface{}, error) {
 	options := options.FindOne()
 	if qb.projection != nil {
 		options.SetProjection(qb.projection)
@@ -101,17 +192,41 @@ func findone(qb *CollectQueryBuilder, result interface{}) (interface{}, error) {
 	if qb.skip != 0 {
 		options.SetSkip(qb.skip)
 	}
-	if qb.popFields != nil {
-		result := reflect.New(qb.c.modelType.Elem()).Interface()
-		resp, err := qb.virtual(qb.popFields, result, qb.filter)
+	if qb.hint != nil {
+		options.SetHint(qb.hint)
+	}
+	if qb.collation != nil {
+		options.SetCollation(qb.collation)
+	}
+
+	filter, err := qb.buildFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	op := &Operation{Name: HookFindOne, Filter: filter}
+	if err := qb.c.runHooks(ctx, true, op); err != nil {
+		return nil, err
+	}
+
+	if len(qb.popPaths) > 0 {
+		_, err := qb.virtual(ctx, qb.popPaths, result, op.Filter)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		err := qb.c.collection.FindOne(ctx, op.Filter, options).Decode(result)
 		if err != nil {
 			return nil, err
 		}
-		return resp, nil
 	}
 
-	err := qb.c.collection.FindOne(context.Background(), qb.filter, options).Decode(result)
-	if err != nil {
+	if err := callModelHook(ctx, result, "AfterFind"); err != nil {
+		return nil, err
+	}
+
+	op.Result = result
+	if err := qb.c.runHooks(ctx, false, op); err != nil {
 		return nil, err
 	}
 
@@ -120,76 +235,97 @@ func findone(qb *CollectQueryBuilder, result interface{}) (interface{}, error) {
 
 // FindOneAndUpdate finds a single document in the specified collection based on the filter and updates it.
 // It returns the updated document.
-// The update includes setting the "updatedAt" field to the current time.
+// The update is composed like UpdateOne's, setting the "updatedAt" field to the current time.
+// The fields written by the update are validated against the model's morm tags first.
 func (qb *CollectQueryBuilder) FindOneAndUpdate(filter interface{}, update interface{}, ctx ...context.Context) (interface{}, error) {
-	collection := qb.c.collection
-	var backgroundContext = context.Background()
-	if len(ctx) > 0 {
-		backgroundContext = ctx[0]
+	// Decode the result into the original model
+	modelType, err := getModelType(qb.c.modelElemPtr.Interface())
+	if err != nil {
+		return nil, err
 	}
 
-	// Set updatedAt field to the current time
-	updateWithUpdatedAt := bson.M{
-		"$set": bson.M{
-			"updatedAt": time.Now(),
-		},
+	return findOneAndUpdate(qb, filter, update, reflect.New(modelType).Interface(), ctx...)
+}
+
+// findOneAndUpdate performs the MongoDB findOneAndUpdate operation and decodes the updated document into result.
+func findOneAndUpdate(qb *CollectQueryBuilder, filter interface{}, update interface{}, result interface{}, ctx ...context.Context) (interface{}, error) {
+	collection := qb.c.collection
+	backgroundContext := qb.getContext(ctx...)
+
+	op := &Operation{Name: HookFindOneAndUpdate, Filter: filter, Update: update}
+	if err := qb.beforeUpdate(backgroundContext, op); err != nil {
+		return nil, err
 	}
+	filter, update = op.Filter, op.Update
 
-	// Merge the provided update with the update for updatedAt
-	updateWithUpdatedAt["$set"].(bson.M)["$set"] = update
+	// Merge the timestamp into the update
+	composedUpdate, err := qb.c.composeUpdate(update)
+	if err != nil {
+		return nil, err
+	}
 
 	options := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
-	result := collection.FindOneAndUpdate(backgroundContext, filter, updateWithUpdatedAt, options)
-	if result.Err() != nil {
-		return nil, result.Err()
+	res := collection.FindOneAndUpdate(backgroundContext, filter, composedUpdate, options)
+	if res.Err() != nil {
+		return nil, res.Err()
 	}
 
-	// Decode the result into the original model
-	modelType, err := getModelType(qb.c.modelElemPtr.Interface())
+	err = res.Decode(result)
 	if err != nil {
 		return nil, err
 	}
 
-	resultValue := reflect.New(modelType).Interface()
-	err = result.Decode(resultValue)
-	if err != nil {
+	op.Result = result
+	if err := qb.afterUpdate(backgroundContext, op, result); err != nil {
 		return nil, err
 	}
 
-	return resultValue, nil
+	return result, nil
 }
 
 // FindOneAndRemove finds a single document in the specified collection based on the filter and removes it.
 // It returns the removed document.
 func (qb *CollectQueryBuilder) FindOneAndRemove(filter interface{}, ctx ...context.Context) (interface{}, error) {
+	// Decode the result into the original model
+	modelType, err := getModelType(qb.c.modelElemPtr.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	return findOneAndRemove(qb, filter, reflect.New(modelType).Interface(), ctx...)
+}
+
+// findOneAndRemove performs the MongoDB findOneAndDelete operation and decodes the removed document into result.
+// It returns nil without an error when no document matches the filter.
+func findOneAndRemove(qb *CollectQueryBuilder, filter interface{}, result interface{}, ctx ...context.Context) (interface{}, error) {
 	collection := qb.c.collection
-	var backgroundContext = context.Background()
-	if len(ctx) > 0 {
-		backgroundContext = ctx[0]
+	backgroundContext := qb.getContext(ctx...)
+
+	op := &Operation{Name: HookFindOneAndRemove, Filter: filter}
+	if err := qb.beforeDelete(backgroundContext, op); err != nil {
+		return nil, err
 	}
 
 	options := options.FindOneAndDelete().SetProjection(bson.D{{Key: "_id", Value: 0}})
 
-	result := collection.FindOneAndDelete(backgroundContext, filter, options)
-	if result.Err() != nil {
-		if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+	res := collection.FindOneAndDelete(backgroundContext, op.Filter, options)
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
 			return nil, nil
 		}
-		return nil, result.Err()
+		return nil, res.Err()
 	}
 
-	// Decode the result into the original model
-	modelType, err := getModelType(qb.c.modelElemPtr.Interface())
+	err := res.Decode(result)
 	if err != nil {
 		return nil, err
 	}
 
-	resultValue := reflect.New(modelType).Interface()
-	err = result.Decode(resultValue)
-	if err != nil {
+	op.Result = result
+	if err := qb.afterDelete(backgroundContext, op, result); err != nil {
 		return nil, err
 	}
 
-	return resultValue, nil
+	return result, nil
 }
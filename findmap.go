@@ -0,0 +1,108 @@
+package morm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ExecMap executes the find query and returns the documents as maps instead of decoding them into the model.
+// It honours the filter, projection, sort, skip, limit and populated fields of the query.
+//
+// Parameters:
+//   - ctx: Optional context.Context for the query. If not provided, the builder's context is used.
+//
+// Returns:
+//   - []bson.M: The matching documents.
+//   - error: An error if the query failed.
+//
+// Example:
+//
+//	docs, err := qb.Find(bson.M{"active": true}).Projection(bson.D{{"email", 1}}).ExecMap()
+//
+// This method is commonly used for lean reads where the documents are passed on without processing.
+func (qb *CollectQueryBuilder) ExecMap(ctx ...context.Context) ([]bson.M, error) {
+	res, err := find(qb.getContext(ctx...), qb, func() interface{} {
+		return &bson.M{}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]bson.M, 0, len(res))
+	for _, r := range res {
+		docs = append(docs, *r.(*bson.M))
+	}
+
+	return docs, nil
+}
+
+// ExecMap executes the typed find query and returns the documents as maps instead of decoding them into T.
+func (q *FindQuery[T]) ExecMap(ctx ...context.Context) ([]bson.M, error) {
+	return q.qb.ExecMap(ctx...)
+}
+
+// ExecIndex executes the typed find query and returns the documents keyed by a field.
+// The field is a dotted bson path of T whose values are K or convertible to K.
+// If several documents have the same key, the last one wins.
+//
+// Example:
+//
+//	byEmail, err := morm.ExecIndex[string](users.Find(bson.M{"active": true}), "email")
+//	if err != nil {
+//	  // Handle error
+//	}
+//	user := byEmail["ada@example.com"]
+func ExecIndex[K comparable, T any](q *FindQuery[T], field string, ctx ...context.Context) (map[K]*T, error) {
+	if err := checkField(q.qb.c.modelType, field); err != nil {
+		return nil, err
+	}
+
+	res, err := q.ExecContext(q.qb.getContext(ctx...))
+	if err != nil {
+		return nil, err
+	}
+
+	keyType := reflect.TypeOf((*K)(nil)).Elem()
+	index := make(map[K]*T, len(res))
+	for _, r := range res {
+		value, ok := valueByBSONPath(reflect.ValueOf(r).Elem(), field)
+		if !ok {
+			continue
+		}
+
+		// Integers convert to strings as runes, so only strings make string keys
+		if !value.Type().ConvertibleTo(keyType) || (keyType.Kind() == reflect.String && value.Kind() != reflect.String) {
+			return nil, fmt.Errorf("field %q of type %s cannot be used as a %s key", field, value.Type(), keyType)
+		}
+		index[value.Convert(keyType).Interface().(K)] = r
+	}
+
+	return index, nil
+}
+
+// valueByBSONPath returns the value at a dotted bson path of a struct, following pointers.
+// It reports false if the path does not exist or crosses a nil pointer.
+func valueByBSONPath(value reflect.Value, path string) (reflect.Value, bool) {
+	for _, key := range strings.Split(path, ".") {
+		for value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		field, ok := fieldValueByBSONName(value, key)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		value = field
+	}
+	return value, true
+}
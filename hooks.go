@@ -0,0 +1,198 @@
+package morm
+
+import (
+	"context"
+	"reflect"
+)
+
+// Hook events accepted by Pre and Post.
+const (
+	// HookSave runs around Create.
+	HookSave = "save"
+	// HookUpdateOne runs around UpdateOne.
+	HookUpdateOne = "updateOne"
+	// HookUpdate runs around Update.
+	HookUpdate = "update"
+	// HookReplaceOne runs around the ReplaceOne and UpsertReplace operations of a Bulk.
+	HookReplaceOne = "replaceOne"
+	// HookDeleteOne runs around Delete.
+	HookDeleteOne = "deleteOne"
+	// HookDeleteMany runs around DeleteMany.
+	HookDeleteMany = "deleteMany"
+	// HookFind runs around find queries executed with Exec.
+	HookFind = "find"
+	// HookFindOne runs around findone queries executed with Exec.
+	HookFindOne = "findOne"
+	// HookFindOneAndUpdate runs around FindOneAndUpdate.
+	HookFindOneAndUpdate = "findOneAndUpdate"
+	// HookFindOneAndRemove runs around FindOneAndRemove.
+	HookFindOneAndRemove = "findOneAndRemove"
+	// HookCount runs around Count and DocumentExists.
+	HookCount = "count"
+	// HookEstimatedCount runs around EstimatedCount.
+	HookEstimatedCount = "estimatedCount"
+)
+
+// HookFunc is a middleware function registered with Pre or Post.
+// A pre hook returning an error aborts the operation and the error is returned to the caller.
+// A post hook returning an error is returned to the caller after the operation has completed.
+type HookFunc func(ctx context.Context, op *Operation) error
+
+// Operation describes the operation a hook runs around.
+// Pre hooks may replace Filter, Update or Document; the operation uses the values they leave behind.
+type Operation struct {
+	// Name is the hook event, e.g. HookSave or HookFind.
+	Name string
+	// Filter is the filter of query, update and delete operations.
+	Filter interface{}
+	// Update is the update of update operations.
+	Update interface{}
+	// Document is the document passed to Create.
+	Document interface{}
+	// Result is the result of the operation. It is only set for post hooks.
+	Result interface{}
+}
+
+// BeforeCreator is implemented by models that run logic before they are inserted by Create.
+type BeforeCreator interface {
+	BeforeCreate(ctx context.Context) error
+}
+
+// AfterCreator is implemented by models that run logic after they are inserted by Create.
+type AfterCreator interface {
+	AfterCreate(ctx context.Context) error
+}
+
+// BeforeUpdater is implemented by models that run logic before they are written by an update.
+// It is called on the update value when it is a model, as with UpdateOne(filter, &user), and on the replacement
+// of Bulk ReplaceOne and UpsertReplace operations. It is not called for operator documents such as bson.M or bson.D
+// updates or UpdateBuilder values, which carry no model.
+type BeforeUpdater interface {
+	BeforeUpdate(ctx context.Context) error
+}
+
+// AfterUpdater is implemented by models that run logic after they are written by an update.
+// It is called on the document returned by FindOneAndUpdate, and on the update value or replacement
+// in the same cases as BeforeUpdate.
+type AfterUpdater interface {
+	AfterUpdate(ctx context.Context) error
+}
+
+// BeforeDeleter is implemented by models that run logic before documents of the model are deleted.
+// Deletes do not read the documents they remove, so it is called on a new zero value of the collection's model
+// with the filter of the delete, after the pre hooks, by Delete, DeleteMany, FindOneAndRemove and Bulk deletes.
+// Returning an error aborts the delete.
+type BeforeDeleter interface {
+	BeforeDelete(ctx context.Context, filter interface{}) error
+}
+
+// AfterDeleter is implemented by models that run logic after they are deleted.
+// It is only called by FindOneAndRemove, on the removed document. Delete, DeleteMany and Bulk deletes do not
+// read the deleted documents, so after them only the post hooks registered with Post run.
+type AfterDeleter interface {
+	AfterDelete(ctx context.Context) error
+}
+
+// AfterFinder is implemented by models that run logic after they are decoded from a query result.
+type AfterFinder interface {
+	AfterFind(ctx context.Context) error
+}
+
+// Pre registers a hook that runs before every operation of the given event on the collection.
+// Hooks are kept on the collection, so they apply to every builder created for the same collection and model.
+//
+// Example:
+//
+//	qb.Pre(morm.HookFind, func(ctx context.Context, op *morm.Operation) error {
+//	  op.Filter = bson.M{"$and": bson.A{op.Filter, bson.M{"deleted": false}}}
+//	  return nil
+//	})
+//
+// This method is commonly used for defaults, auditing and scoping queries.
+func (qb *CollectQueryBuilder) Pre(event string, fn HookFunc) *CollectQueryBuilder {
+	qb.c.addHook(true, event, fn)
+	return qb
+}
+
+// Post registers a hook that runs after every successful operation of the given event on the collection.
+//
+// Example:
+//
+//	qb.Post(morm.HookSave, func(ctx context.Context, op *morm.Operation) error {
+//	  log.Printf("created %v", op.Result)
+//	  return nil
+//	})
+//
+// This method is commonly used for side effects such as cache invalidation.
+func (qb *CollectQueryBuilder) Post(event string, fn HookFunc) *CollectQueryBuilder {
+	qb.c.addHook(false, event, fn)
+	return qb
+}
+
+// addHook stores a pre or post hook for an event.
+func (c *Collect) addHook(pre bool, event string, fn HookFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hooks := &c.postHooks
+	if pre {
+		hooks = &c.preHooks
+	}
+	if *hooks == nil {
+		*hooks = make(map[string][]HookFunc)
+	}
+	(*hooks)[event] = append((*hooks)[event], fn)
+}
+
+// runHooks runs the pre or post hooks registered for the operation's event in registration order.
+func (c *Collect) runHooks(ctx context.Context, pre bool, op *Operation) error {
+	c.mu.RLock()
+	hooks := c.postHooks[op.Name]
+	if pre {
+		hooks = c.preHooks[op.Name]
+	}
+	c.mu.RUnlock()
+
+	for _, hook := range hooks {
+		if err := hook(ctx, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newModel returns a pointer to a new zero value of the collection's model.
+func (c *Collect) newModel() interface{} {
+	return reflect.New(c.modelType.Elem()).Interface()
+}
+
+// callModelHook calls the model-level hook implemented by v, if any.
+func callModelHook(ctx context.Context, v interface{}, hook string) error {
+	switch hook {
+	case "BeforeCreate":
+		if h, ok := v.(BeforeCreator); ok {
+			return h.BeforeCreate(ctx)
+		}
+	case "AfterCreate":
+		if h, ok := v.(AfterCreator); ok {
+			return h.AfterCreate(ctx)
+		}
+	case "BeforeUpdate":
+		if h, ok := v.(BeforeUpdater); ok {
+			return h.BeforeUpdate(ctx)
+		}
+	case "AfterUpdate":
+		if h, ok := v.(AfterUpdater); ok {
+			return h.AfterUpdate(ctx)
+		}
+	case "AfterDelete":
+		if h, ok := v.(AfterDeleter); ok {
+			return h.AfterDelete(ctx)
+		}
+	case "AfterFind":
+		if h, ok := v.(AfterFinder); ok {
+			return h.AfterFind(ctx)
+		}
+	}
+	return nil
+}
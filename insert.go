@@ -7,6 +7,10 @@ import (
 )
 
 // Create inserts a new document into the specified collection.
+// The model is validated against its morm tags before it is inserted.
+// Pre and post "save" hooks and the BeforeCreate and AfterCreate model hooks run around the insert.
+// When model is a pointer to a struct, its ID, CreatedAt and UpdatedAt fields are set before the insert
+// according to the collection's TimestampPolicy, and zero fields tagged `morm:"default=value"` are set to their default.
 //
 // Parameters:
 //   - model: The model representing the document to be inserted.
@@ -14,18 +18,24 @@ import (
 //
 // Returns:
 //   - primitive.ObjectID: The ObjectID of the newly inserted document.
-//   - error: An error if any occurred during the insert operation.
+//   - error: A *ValidationError if the model is invalid, or an error if any occurred during the insert operation.
 func (qb *CollectQueryBuilder) Create(model interface{}, ctx ...context.Context) (primitive.ObjectID, error) {
-	collection := qb.c.collection
-	var backgroundContext = context.Background()
-	if len(ctx) > 0 {
-		backgroundContext = ctx[0]
+	backgroundContext := qb.getContext(ctx...)
+
+	op, err := prepareInsert(backgroundContext, qb, model)
+	if err != nil {
+		return primitive.NilObjectID, err
 	}
 
-	res, err := collection.InsertOne(backgroundContext, model)
+	res, err := qb.c.collection.InsertOne(backgroundContext, op.Document)
 	if err != nil {
 		return primitive.NilObjectID, err
 	}
+	id, _ := res.InsertedID.(primitive.ObjectID)
+
+	if err := afterInsert(backgroundContext, qb, op, id); err != nil {
+		return id, err
+	}
 
-	return res.InsertedID.(primitive.ObjectID), nil
+	return id, nil
 }
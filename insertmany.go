@@ -0,0 +1,235 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaultChunkSize is the number of documents CreateMany sends per InsertMany call by default.
+const defaultChunkSize = 1000
+
+// BulkOption configures a batch write: CreateMany or Bulk.Execute.
+type BulkOption func(*bulkConfig)
+
+// bulkConfig holds the settings of a batch write.
+type bulkConfig struct {
+	ordered   bool
+	chunkSize int
+}
+
+// Ordered sets whether a batch write stops at the first failed write (true, the default)
+// or attempts every write and reports all failures (false).
+func Ordered(ordered bool) BulkOption {
+	return func(c *bulkConfig) {
+		c.ordered = ordered
+	}
+}
+
+// ChunkSize sets the maximum number of writes sent to the server in a single request.
+// CreateMany defaults to 1000 documents, and Bulk sends every operation in one BulkWrite,
+// which the driver splits by the server's maximum write batch size.
+// Values less than 1 keep the default.
+func ChunkSize(n int) BulkOption {
+	return func(c *bulkConfig) {
+		if n > 0 {
+			c.chunkSize = n
+		}
+	}
+}
+
+// newBulkConfig returns the settings of a batch write with the options applied.
+func newBulkConfig(opts []BulkOption) bulkConfig {
+	config := bulkConfig{ordered: true}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// BulkError is returned by batch writes when some of the writes failed.
+// Errors maps the index of each failed write in the input to its cause, such as a *ValidationError
+// or a mongo.WriteError for a duplicate key, which mongo.IsDuplicateKeyError recognizes.
+//
+// Example:
+//
+//	ids, err := qb.CreateMany(ctx, docs, morm.Ordered(false))
+//	var bulkErr *morm.BulkError
+//	if errors.As(err, &bulkErr) {
+//	  for i, cause := range bulkErr.Errors {
+//	    log.Printf("document %d: %v", i, cause)
+//	  }
+//	}
+type BulkError struct {
+	Errors map[int]error
+}
+
+// Error returns the number of failed writes and the first failure.
+func (e *BulkError) Error() string {
+	indexes := e.indexes()
+	if len(indexes) == 0 {
+		return "bulk write failed"
+	}
+	return fmt.Sprintf("%d writes failed, first at index %d: %v", len(indexes), indexes[0], e.Errors[indexes[0]])
+}
+
+// Unwrap returns the causes ordered by index, so errors.Is and errors.As match any of them.
+func (e *BulkError) Unwrap() []error {
+	var errs []error
+	for _, i := range e.indexes() {
+		errs = append(errs, e.Errors[i])
+	}
+	return errs
+}
+
+// indexes returns the failed indexes in ascending order.
+func (e *BulkError) indexes() []int {
+	indexes := make([]int, 0, len(e.Errors))
+	for i := range e.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+// CreateMany inserts documents into the collection in chunks of InsertMany calls.
+// Every document goes through the same steps as with Create: the pre "save" hooks and BeforeCreate run,
+// its ID, defaults and timestamps are set, and it is validated against its morm tags.
+// Documents that fail a step are not inserted.
+//
+// In ordered mode, the default, the insert stops at the first failed document and the documents after it
+// are not inserted. With Ordered(false), every valid document is inserted and all failures are reported.
+//
+// Parameters:
+//   - ctx: The context.Context used for the inserts.
+//   - docs: The documents to insert.
+//   - opts: Ordered and ChunkSize options.
+//
+// Returns:
+//   - []primitive.ObjectID: The ObjectIDs of the documents by their index in docs. The IDs of documents that
+//     were not inserted are primitive.NilObjectID.
+//   - error: A *BulkError mapping the index of each failed document to its cause,
+//     or an error if a chunk failed as a whole.
+//
+// Example:
+//
+//	ids, err := qb.CreateMany(ctx, docs, morm.Ordered(false), morm.ChunkSize(1000))
+func (qb *CollectQueryBuilder) CreateMany(ctx context.Context, docs []interface{}, opts ...BulkOption) ([]primitive.ObjectID, error) {
+	config := newBulkConfig(opts)
+	if config.chunkSize == 0 {
+		config.chunkSize = defaultChunkSize
+	}
+	ids := make([]primitive.ObjectID, len(docs))
+	failed := map[int]error{}
+
+	// Prepare and validate every document before anything is written
+	var ops []*Operation
+	var indexes []int
+	for i, doc := range docs {
+		op, err := prepareInsert(ctx, qb, doc)
+		if err != nil {
+			failed[i] = err
+			if config.ordered {
+				break
+			}
+			continue
+		}
+		ops = append(ops, op)
+		indexes = append(indexes, i)
+	}
+
+	for start := 0; start < len(ops); start += config.chunkSize {
+		end := start + config.chunkSize
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		chunk := make([]interface{}, 0, end-start)
+		for _, op := range ops[start:end] {
+			chunk = append(chunk, op.Document)
+		}
+
+		res, err := qb.c.collection.InsertMany(ctx, chunk, options.InsertMany().SetOrdered(config.ordered))
+		var bulkErr mongo.BulkWriteException
+		if err != nil && (!errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil) {
+			return ids, err
+		}
+
+		// An ordered insert stops at its first write error
+		inserted := end - start
+		chunkFailed := map[int]bool{}
+		for _, writeErr := range bulkErr.WriteErrors {
+			cause := writeErr.WriteError
+			cause.Index = indexes[start+writeErr.Index]
+			failed[cause.Index] = cause
+			chunkFailed[writeErr.Index] = true
+			if config.ordered && writeErr.Index < inserted {
+				inserted = writeErr.Index
+			}
+		}
+
+		for j := 0; j < inserted; j++ {
+			if chunkFailed[j] {
+				continue
+			}
+			i := indexes[start+j]
+			op := ops[start+j]
+			if res != nil && j < len(res.InsertedIDs) {
+				ids[i], _ = res.InsertedIDs[j].(primitive.ObjectID)
+			}
+			if err := afterInsert(ctx, qb, op, ids[i]); err != nil {
+				failed[i] = err
+			}
+		}
+
+		if config.ordered && len(bulkErr.WriteErrors) > 0 {
+			break
+		}
+	}
+
+	if len(failed) > 0 {
+		return ids, &BulkError{Errors: failed}
+	}
+	return ids, nil
+}
+
+// prepareInsert runs the steps of Create that precede the insert of a document.
+func prepareInsert(ctx context.Context, qb *CollectQueryBuilder, doc interface{}) (*Operation, error) {
+	op := &Operation{Name: HookSave, Document: doc}
+	if err := qb.c.runHooks(ctx, true, op); err != nil {
+		return nil, err
+	}
+	if err := callModelHook(ctx, op.Document, "BeforeCreate"); err != nil {
+		return nil, err
+	}
+	if err := qb.c.prepareCreate(op.Document); err != nil {
+		return nil, err
+	}
+	if err := validateDocument(ctx, qb, op.Document); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// afterInsert runs the steps of Create that follow the insert of a document.
+func afterInsert(ctx context.Context, qb *CollectQueryBuilder, op *Operation, id primitive.ObjectID) error {
+	if err := callModelHook(ctx, op.Document, "AfterCreate"); err != nil {
+		return err
+	}
+	op.Result = id
+	return qb.c.runHooks(ctx, false, op)
+}
+
+// CreateMany inserts documents into the collection. See CollectQueryBuilder.CreateMany.
+func (tc *TypedCollection[T]) CreateMany(ctx context.Context, docs []*T, opts ...BulkOption) ([]primitive.ObjectID, error) {
+	values := make([]interface{}, 0, len(docs))
+	for _, doc := range docs {
+		values = append(values, doc)
+	}
+	return tc.Query().CreateMany(ctx, values, opts...)
+}
@@ -3,57 +3,234 @@ package morm
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"reflect"
+	"sync"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// DefaultConnection is the name under which the connection established by Connect is registered.
+const DefaultConnection = "default"
+
+// ErrNotConnected is returned when a collection is requested from a connection that has not been established.
+var ErrNotConnected = errors.New("no MongoDB connection")
+
 // MongoDBInstance represents a global instance of MongoDB connection.
+// It is the default connection used by Collection and For.
 var MongoDBInstance *MongoDB
 
+var (
+	connectionsMu sync.RWMutex
+	connections   = make(map[string]*DB)
+)
+
 // Connect establishes a connection to MongoDB and returns a MongoDB instance.
 // It takes the MongoDB URI and the name of the database as parameters.
-func Connect(uri string, dbName string) (*MongoDB, error) {
-	clientOptions := options.Client().ApplyURI(uri)
-	client, err := mongo.Connect(context.Background(), clientOptions)
+// The connection becomes the default connection stored in MongoDBInstance.
+// Optional ConnectOption values configure the underlying client.
+//
+// Example:
+//
+//	_, err := morm.Connect("mongodb://localhost:27017", "app",
+//	  morm.WithAppName("api"),
+//	  morm.WithMaxPoolSize(50),
+//	  morm.WithStartupRetry(5, time.Second),
+//	)
+func Connect(uri string, dbName string, opts ...ConnectOption) (*MongoDB, error) {
+	db, err := connect(uri, dbName, opts)
+	if err != nil {
+		return nil, err
+	}
 
+	Register(DefaultConnection, db)
+	return db, nil
+}
+
+// ConnectNamed establishes a connection to MongoDB and registers it under the given name.
+// The connection can later be retrieved with Use.
+//
+// Example:
+//
+//	_, err := morm.ConnectNamed("analytics", "mongodb://analytics:27017", "events")
+//	if err != nil {
+//	  // Handle error
+//	}
+//	qb, err := morm.Use("analytics").Collection("pageviews", &PageView{})
+func ConnectNamed(name string, uri string, dbName string, opts ...ConnectOption) (*DB, error) {
+	db, err := connect(uri, dbName, opts)
 	if err != nil {
-		log.Fatal(err)
 		return nil, err
 	}
 
-	err = client.Ping(context.Background(), nil)
+	Register(name, db)
+	return db, nil
+}
+
+// connect creates a MongoDB client for the URI and, unless disabled, verifies it with a ping.
+// Failed attempts are retried according to the startup retry option.
+func connect(uri string, dbName string, opts []ConnectOption) (*DB, error) {
+	cfg := newConnectConfig(uri, opts)
+
+	backoff := cfg.retryBackoff
+	for attempt := 0; ; attempt++ {
+		client, err := dial(cfg)
+		if err == nil {
+			return &DB{Client: client, DBName: dbName, naming: cfg.naming}, nil
+		}
+		if attempt >= cfg.retryAttempts {
+			return nil, err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// dial performs a single connection attempt with the collected client options.
+func dial(cfg *connectConfig) (*mongo.Client, error) {
+	client, err := mongo.Connect(context.Background(), cfg.clientOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.ping {
+		return client, nil
+	}
+
+	pingContext := context.Background()
+	if cfg.connectTimeout > 0 {
+		var cancel context.CancelFunc
+		pingContext, cancel = context.WithTimeout(pingContext, cfg.connectTimeout)
+		defer cancel()
+	}
+
+	err = client.Ping(pingContext, nil)
 	if err != nil {
-		log.Fatal(err)
+		_ = client.Disconnect(context.Background())
 		return nil, err
 	}
 
-	fmt.Println("Successfully connected to MongoDB")
-	MongoDBInstance = &MongoDB{Client: client, DBName: dbName}
-	return MongoDBInstance, nil
+	return client, nil
+}
+
+// Disconnect closes the default connection and removes it from MongoDBInstance.
+func Disconnect(ctx context.Context) error {
+	db := Use(DefaultConnection)
+	if db == nil {
+		return ErrNotConnected
+	}
+
+	err := db.Disconnect(ctx)
+	if err != nil {
+		return err
+	}
+
+	Register(DefaultConnection, nil)
+	return nil
+}
+
+// Disconnect closes the underlying client of the connection.
+// Operations on collections created from the connection fail afterwards.
+func (db *DB) Disconnect(ctx context.Context) error {
+	if db == nil || db.Client == nil {
+		return ErrNotConnected
+	}
+
+	return db.Client.Disconnect(ctx)
 }
 
-// Collection creates a new Collect instance for the specified collection and model.
+// Register stores a connection under the given name.
+// Registering under DefaultConnection replaces MongoDBInstance.
+func Register(name string, db *DB) {
+	connectionsMu.Lock()
+	defer connectionsMu.Unlock()
+
+	if name == DefaultConnection {
+		MongoDBInstance = db
+		return
+	}
+	connections[name] = db
+}
+
+// Unregister removes the connection registered under the given name without disconnecting it.
+// Unregistering DefaultConnection clears MongoDBInstance.
+//
+// Example:
+//
+//	db := morm.Use("analytics")
+//	morm.Unregister("analytics")
+//	err := db.Disconnect(ctx)
+func Unregister(name string) {
+	connectionsMu.Lock()
+	defer connectionsMu.Unlock()
+
+	if name == DefaultConnection {
+		MongoDBInstance = nil
+		return
+	}
+	delete(connections, name)
+}
+
+// Use returns the connection registered under the given name, or nil if there is none.
+// Use(DefaultConnection) returns MongoDBInstance.
+func Use(name string) *DB {
+	connectionsMu.RLock()
+	defer connectionsMu.RUnlock()
+
+	if name == DefaultConnection {
+		return MongoDBInstance
+	}
+	return connections[name]
+}
+
+// Collection creates a new Collect instance for the specified collection and model on the default connection.
 // It takes the collection name and a model (pointer to a struct) as parameters.
 // Returns a CollectQueryBuilder for building queries on the collection.
 func Collection(collectionName string, model interface{}) (*CollectQueryBuilder, error) {
-	collection := MongoDBInstance.Client.Database(MongoDBInstance.DBName).Collection(collectionName)
+	return Use(DefaultConnection).Collection(collectionName, model)
+}
+
+// Collection creates a new Collect instance for the specified collection and model on this connection.
+// It takes the collection name and a model (pointer to a struct) as parameters.
+// Returns a CollectQueryBuilder for building queries on the collection.
+// Builders for the same collection and model share the hooks registered with Pre and Post.
+func (db *DB) Collection(collectionName string, model interface{}) (*CollectQueryBuilder, error) {
+	if db == nil || db.Client == nil {
+		return nil, ErrNotConnected
+	}
+
+	collection := db.Client.Database(db.DBName).Collection(collectionName)
 	modelType := reflect.TypeOf(model)
 
-	if modelType.Kind() != reflect.Ptr || modelType.Elem().Kind() != reflect.Struct {
+	if modelType == nil || modelType.Kind() != reflect.Ptr || modelType.Elem().Kind() != reflect.Struct {
 		return nil, fmt.Errorf("model must be a pointer to a struct")
 	}
 
+	db.collectsMu.Lock()
+	defer db.collectsMu.Unlock()
+
+	// Reuse the Collect of the collection and model so hooks registered on it persist
+	key := collectKey{name: collectionName, modelType: modelType}
+	if c, ok := db.collects[key]; ok {
+		return &CollectQueryBuilder{c: c}, nil
+	}
+
 	modelElemPtr := reflect.New(modelType.Elem())
 
 	c := &Collect{
+		db:           db,
 		collection:   collection,
 		modelType:    modelType,
 		modelElemPtr: modelElemPtr,
 	}
 
+	if db.collects == nil {
+		db.collects = make(map[collectKey]*Collect)
+	}
+	db.collects[key] = c
+
 	return &CollectQueryBuilder{c: c}, nil
 }
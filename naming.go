@@ -0,0 +1,168 @@
+package morm
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy maps the name of a populated field to the collection it references.
+// It is used by Populate for fields without a ref tag whose model type is not registered with RegisterModel.
+type NamingStrategy func(name string) string
+
+// LowerPlural lowercases the name and appends "s", e.g. "Category" becomes "categorys".
+// It is the default strategy, kept for backwards compatibility.
+func LowerPlural(name string) string {
+	return strings.ToLower(name) + "s"
+}
+
+// EnglishPlural lowercases the name and applies English plural rules, e.g. "Category" becomes
+// "categories" and "Box" becomes "boxes".
+func EnglishPlural(name string) string {
+	return pluralize(strings.ToLower(name))
+}
+
+// SnakeCase converts the name to snake_case without pluralizing it, e.g. "BlogPost" becomes "blog_post".
+func SnakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word at an uppercase letter that follows a lowercase letter or starts a new word
+			// after an acronym, e.g. "HTTPServer" becomes "http_server"
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// SnakeCasePlural converts the name to snake_case and pluralizes the last word, e.g. "BlogCategory"
+// becomes "blog_categories".
+func SnakeCasePlural(name string) string {
+	return pluralize(SnakeCase(name))
+}
+
+// Explicit maps names to collections with a fixed table, falling back to another strategy
+// for names that are not listed.
+//
+// Example:
+//
+//	morm.Explicit(map[string]string{"Author": "users"}, morm.EnglishPlural)
+func Explicit(collections map[string]string, fallback NamingStrategy) NamingStrategy {
+	return func(name string) string {
+		if collection, ok := collections[name]; ok {
+			return collection
+		}
+		return fallback(name)
+	}
+}
+
+// SetNamingStrategy sets the naming strategy used by Populate on the connection.
+func (db *DB) SetNamingStrategy(strategy NamingStrategy) {
+	db.collectsMu.Lock()
+	defer db.collectsMu.Unlock()
+
+	db.naming = strategy
+}
+
+// RegisterModel registers the collection documents of a model type are stored in on the connection.
+// Populate reads fields of the model type, and refPath references naming the type, from that collection
+// when they have no ref tag.
+//
+// Example:
+//
+//	err := db.RegisterModel(&Author{}, "users")
+func (db *DB) RegisterModel(model interface{}, collection string) error {
+	if db == nil || db.Client == nil {
+		return ErrNotConnected
+	}
+
+	modelType := reflect.TypeOf(model)
+	for modelType != nil && modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType == nil || modelType.Kind() != reflect.Struct {
+		return errors.New("model must be a struct or a pointer to a struct")
+	}
+	if collection == "" {
+		return errors.New("collection name must not be empty")
+	}
+
+	db.collectsMu.Lock()
+	defer db.collectsMu.Unlock()
+
+	if db.models == nil {
+		db.models = make(map[reflect.Type]string)
+	}
+	db.models[modelType] = collection
+	return nil
+}
+
+// RegisterModel registers the collection of a model type on the default connection. See DB.RegisterModel.
+func RegisterModel(model interface{}, collection string) error {
+	return Use(DefaultConnection).RegisterModel(model, collection)
+}
+
+// refCollection returns the collection a populated field references: the ref tag, then the collection
+// registered with RegisterModel for the field's model type, then the name derived from the field name
+// with the naming strategy.
+func (db *DB) refCollection(field reflect.StructField) string {
+	if ref := field.Tag.Get("ref"); ref != "" {
+		return ref
+	}
+
+	db.collectsMu.Lock()
+	registered, ok := db.models[elemType(field.Type)]
+	db.collectsMu.Unlock()
+
+	if ok {
+		return registered
+	}
+	return db.collectionName(field.Name)
+}
+
+// kindCollection returns the collection named by a refPath discriminator: the collection registered with
+// RegisterModel for a model type of that name, or the name derived with the naming strategy.
+func (db *DB) kindCollection(kind string) string {
+	db.collectsMu.Lock()
+	for modelType, collection := range db.models {
+		if modelType.Name() == kind {
+			db.collectsMu.Unlock()
+			return collection
+		}
+	}
+	db.collectsMu.Unlock()
+
+	return db.collectionName(kind)
+}
+
+// collectionName returns the collection referenced by a populated field name under the connection's strategy.
+func (db *DB) collectionName(name string) string {
+	db.collectsMu.Lock()
+	strategy := db.naming
+	db.collectsMu.Unlock()
+
+	if strategy == nil {
+		strategy = LowerPlural
+	}
+	return strategy(name)
+}
+
+// pluralize applies English plural rules to a lowercase word.
+func pluralize(word string) string {
+	switch {
+	case word == "":
+		return word
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsAny(word[len(word)-2:len(word)-1], "aeiou"):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	}
+	return word + "s"
+}
@@ -0,0 +1,140 @@
+package morm
+
+import (
+	"crypto/tls"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// ConnectOption configures how Connect and ConnectNamed establish a connection.
+// Options are layered on top of options.Client().ApplyURI(uri), so they override settings from the URI.
+type ConnectOption func(*connectConfig)
+
+// connectConfig holds the settings collected from ConnectOption values.
+type connectConfig struct {
+	clientOptions  *options.ClientOptions
+	ping           bool
+	retryAttempts  int
+	retryBackoff   time.Duration
+	connectTimeout time.Duration
+	naming         NamingStrategy
+}
+
+// newConnectConfig applies the options to a configuration built from the MongoDB URI.
+func newConnectConfig(uri string, opts []ConnectOption) *connectConfig {
+	cfg := &connectConfig{
+		clientOptions: options.Client().ApplyURI(uri),
+		ping:          true,
+		retryBackoff:  500 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithMaxPoolSize sets the maximum number of connections in the client connection pool.
+func WithMaxPoolSize(n uint64) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.clientOptions.SetMaxPoolSize(n)
+	}
+}
+
+// WithMinPoolSize sets the minimum number of connections kept in the client connection pool.
+func WithMinPoolSize(n uint64) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.clientOptions.SetMinPoolSize(n)
+	}
+}
+
+// WithConnectTimeout sets the timeout for establishing a connection to a server.
+// The same timeout bounds the ping performed on connect.
+func WithConnectTimeout(d time.Duration) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.clientOptions.SetConnectTimeout(d)
+		cfg.connectTimeout = d
+	}
+}
+
+// WithServerSelectionTimeout sets how long the driver waits to find an available server for an operation.
+func WithServerSelectionTimeout(d time.Duration) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.clientOptions.SetServerSelectionTimeout(d)
+	}
+}
+
+// WithSocketTimeout sets how long the driver waits for a socket read or write to return.
+func WithSocketTimeout(d time.Duration) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.clientOptions.SetSocketTimeout(d)
+	}
+}
+
+// WithAppName sets the application name sent to the server in the connection handshake.
+func WithAppName(name string) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.clientOptions.SetAppName(name)
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used for connections to the server.
+func WithTLSConfig(config *tls.Config) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.clientOptions.SetTLSConfig(config)
+	}
+}
+
+// WithCompressors sets the compressors the client may use to communicate with the server,
+// e.g. "snappy", "zlib" or "zstd".
+func WithCompressors(compressors ...string) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.clientOptions.SetCompressors(compressors)
+	}
+}
+
+// WithReadPreference sets the read preference for operations run through the client.
+func WithReadPreference(rp *readpref.ReadPref) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.clientOptions.SetReadPreference(rp)
+	}
+}
+
+// WithRetryWrites sets whether supported write operations are retried once on network errors.
+func WithRetryWrites(retry bool) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.clientOptions.SetRetryWrites(retry)
+	}
+}
+
+// WithPingOnConnect sets whether Connect pings the server before returning. It defaults to true.
+// Disabling the ping lets the application start while the server is unreachable.
+func WithPingOnConnect(ping bool) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.ping = ping
+	}
+}
+
+// WithStartupRetry retries a failed connection attempt up to attempts more times.
+// The wait between attempts starts at backoff and doubles after every failure.
+func WithStartupRetry(attempts int, backoff time.Duration) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.retryAttempts = attempts
+		cfg.retryBackoff = backoff
+	}
+}
+
+// WithNamingStrategy sets the naming strategy used by Populate on the connection.
+func WithNamingStrategy(strategy NamingStrategy) ConnectOption {
+	return func(cfg *connectConfig) {
+		cfg.naming = strategy
+	}
+}
+
+// WithClientOptions gives direct access to the driver client options for settings not covered by other options.
+func WithClientOptions(fn func(*options.ClientOptions)) ConnectOption {
+	return func(cfg *connectConfig) {
+		fn(cfg.clientOptions)
+	}
+}
@@ -0,0 +1,303 @@
+package morm
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrInvalidPageToken is returned by Paginate when a page token is malformed or was issued for another sort order.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Page selects a page of a find query for Paginate.
+// Pages are selected with the tokens of a previous page, which keeps deep pages as fast as the first one,
+// or with a page number, which skips the documents of the previous pages.
+type Page struct {
+	// Size is the number of documents per page.
+	Size int64
+	// After selects the page following the page that returned it as NextToken.
+	After string
+	// Before selects the page preceding the page that returned it as PrevToken.
+	Before string
+	// Number selects a page by its number, starting at 1, when no token is set.
+	Number int64
+	// WithTotal also counts the documents matching the query.
+	WithTotal bool
+}
+
+// PageResult is a page of documents returned by Paginate.
+type PageResult[T any] struct {
+	// Items are the documents of the page.
+	Items []T
+	// NextToken selects the next page with Page.After. It is empty on the last page.
+	NextToken string
+	// PrevToken selects the previous page with Page.Before. It is empty on the first page.
+	PrevToken string
+	// HasMore reports whether there is a next page.
+	HasMore bool
+	// Total is the number of documents matching the query, when Page.WithTotal is set.
+	Total int64
+}
+
+// pageToken is the encoded form of a page token: the sort keys and the values of a document for them.
+type pageToken struct {
+	Keys   []string `bson:"k"`
+	Values bson.A   `bson:"v"`
+}
+
+// Paginate executes the find query for a page of documents.
+// The documents are ordered by the query's sort followed by _id, which makes the order unique.
+// Tokens are opaque encodings of the sort values of the first and last documents of the page,
+// and the next or previous page is queried from those values instead of skipping documents.
+// The query's Skip and Limit are replaced by the page, and every sort key must be a field of the model.
+//
+// Parameters:
+//   - ctx: The context.Context used for the queries.
+//   - page: The page to return.
+//
+// Returns:
+//   - *PageResult[interface{}]: The documents of the page, decoded into the model, and the page metadata.
+//   - error: ErrInvalidPageToken for a bad token, or an error if a sort key is not a field of the model,
+//     a token cannot be encoded or the query failed.
+//
+// Example:
+//
+//	res, err := qb.Find(bson.M{"active": true}).Sort(bson.D{{"createdAt", -1}}).Paginate(ctx, morm.Page{Size: 20, After: token})
+//	if err != nil {
+//	  // Handle error
+//	}
+//	next := res.NextToken
+func (qb *CollectQueryBuilder) Paginate(ctx context.Context, page Page) (*PageResult[interface{}], error) {
+	return paginate(ctx, qb, page, func() interface{} {
+		return reflect.New(qb.c.modelType.Elem()).Interface()
+	})
+}
+
+// Paginate executes the typed find query for a page of documents. See CollectQueryBuilder.Paginate.
+func (q *FindQuery[T]) Paginate(ctx context.Context, page Page) (*PageResult[*T], error) {
+	res, err := paginate(ctx, q.qb, page, func() interface{} {
+		return new(T)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*T, 0, len(res.Items))
+	for _, item := range res.Items {
+		items = append(items, item.(*T))
+	}
+
+	return &PageResult[*T]{
+		Items:     items,
+		NextToken: res.NextToken,
+		PrevToken: res.PrevToken,
+		HasMore:   res.HasMore,
+		Total:     res.Total,
+	}, nil
+}
+
+// paginate runs the page query, decoding documents into values returned by newResult.
+func paginate(ctx context.Context, qb *CollectQueryBuilder, page Page, newResult func() interface{}) (*PageResult[interface{}], error) {
+	if page.Size <= 0 {
+		return nil, errors.New("page size must be positive")
+	}
+	if page.After != "" && page.Before != "" {
+		return nil, errors.New("page cannot be both after and before a token")
+	}
+	if page.Number > 0 && (page.After != "" || page.Before != "") {
+		return nil, errors.New("page number cannot be combined with a page token")
+	}
+
+	keys := pageSortKeys(qb.sort)
+	for _, key := range keys {
+		if _, ok := lookupField(qb.c.modelType, key.Key); !ok {
+			return nil, fmt.Errorf("cannot paginate by %q, which is not a field of the model", key.Key)
+		}
+	}
+	backward := page.Before != ""
+	token := page.After
+	if backward {
+		token = page.Before
+	}
+
+	// Fetch one more document than the page size to tell whether there is another page
+	pageQuery := *qb
+	pageQuery.sort = keys
+	pageQuery.skip = 0
+	pageQuery.limit = page.Size + 1
+	if page.Number > 1 {
+		pageQuery.skip = (page.Number - 1) * page.Size
+	}
+	if token != "" {
+		values, err := decodePageToken(token, keys)
+		if err != nil {
+			return nil, err
+		}
+		pageQuery.whereClauses = append(append([]bson.D(nil), qb.whereClauses...), keysetFilter(keys, values, backward))
+	}
+	if backward {
+		pageQuery.sort = reverseSort(keys)
+	}
+
+	items, err := find(ctx, &pageQuery, newResult)
+	if err != nil {
+		return nil, err
+	}
+
+	more := int64(len(items)) > page.Size
+	if more {
+		items = items[:page.Size]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	result := &PageResult[interface{}]{Items: items}
+	if len(items) > 0 {
+		first, err := encodePageToken(keys, items[0])
+		if err != nil {
+			return nil, err
+		}
+		last, err := encodePageToken(keys, items[len(items)-1])
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case backward:
+			// Going back from a page, so there is a page after this one
+			result.NextToken = last
+			if more {
+				result.PrevToken = first
+			}
+		default:
+			if token != "" || page.Number > 1 {
+				result.PrevToken = first
+			}
+			if more {
+				result.NextToken = last
+			}
+		}
+	}
+	result.HasMore = result.NextToken != ""
+
+	if page.WithTotal {
+		countQuery := *qb
+		countQuery.skip = 0
+		countQuery.limit = 0
+		result.Total, err = count(ctx, &countQuery)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// pageSortKeys returns the sort keys of a page query: the query's sort followed by _id, unless it already sorts by _id.
+func pageSortKeys(sort bson.D) bson.D {
+	keys := append(bson.D{}, sort...)
+	for _, key := range keys {
+		if key.Key == "_id" {
+			return keys
+		}
+	}
+	return append(keys, bson.E{Key: "_id", Value: 1})
+}
+
+// reverseSort returns the sort with every direction inverted.
+func reverseSort(sort bson.D) bson.D {
+	reversed := make(bson.D, 0, len(sort))
+	for _, key := range sort {
+		reversed = append(reversed, bson.E{Key: key.Key, Value: -sortDirection(key.Value)})
+	}
+	return reversed
+}
+
+// sortDirection returns -1 for a descending sort value and 1 otherwise.
+func sortDirection(value interface{}) int {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return -1
+		}
+	case reflect.Float32, reflect.Float64:
+		if v.Float() < 0 {
+			return -1
+		}
+	}
+	return 1
+}
+
+// keysetFilter returns the filter selecting the documents after the values in the sort order,
+// or before them when backward is set.
+//
+// For keys a and b, the documents after (x, y) are those with a after x, or a equal to x and b after y.
+func keysetFilter(keys bson.D, values bson.A, backward bool) bson.D {
+	var clauses bson.A
+	for i, key := range keys {
+		clause := bson.D{}
+		for j := 0; j < i; j++ {
+			clause = append(clause, bson.E{Key: keys[j].Key, Value: values[j]})
+		}
+
+		operator := "$gt"
+		if (sortDirection(key.Value) < 0) != backward {
+			operator = "$lt"
+		}
+		clause = append(clause, bson.E{Key: key.Key, Value: bson.D{{Key: operator, Value: values[i]}}})
+		clauses = append(clauses, clause)
+	}
+	return bson.D{{Key: "$or", Value: clauses}}
+}
+
+// encodePageToken encodes the values of the sort keys of a decoded document.
+// A key below a nil pointer is encoded as null, the value the document holds for it.
+func encodePageToken(keys bson.D, item interface{}) (string, error) {
+	token := pageToken{}
+	for _, key := range keys {
+		token.Keys = append(token.Keys, key.Key)
+
+		var value interface{}
+		if field, ok := valueByBSONPath(reflect.ValueOf(item), key.Key); ok {
+			value = field.Interface()
+		}
+		token.Values = append(token.Values, value)
+	}
+
+	data, err := bson.Marshal(token)
+	if err != nil {
+		return "", fmt.Errorf("encode page token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken decodes the sort values of a token, checking it was issued for the same sort keys.
+func decodePageToken(encoded string, keys bson.D) (bson.A, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var token pageToken
+	if err := bson.Unmarshal(data, &token); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	if len(token.Keys) != len(keys) || len(token.Values) != len(keys) {
+		return nil, ErrInvalidPageToken
+	}
+	for i, key := range keys {
+		if token.Keys[i] != key.Key {
+			return nil, ErrInvalidPageToken
+		}
+	}
+
+	return token.Values, nil
+}
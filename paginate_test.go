@@ -0,0 +1,80 @@
+package morm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type pageAddress struct {
+	City string `bson:"city"`
+}
+
+type pageItem struct {
+	Model   `bson:",inline"`
+	Number  int          `bson:"number"`
+	Address *pageAddress `bson:"address"`
+}
+
+// TestPageToken tests that tokens round-trip the sort values of a document and are bound to their sort keys
+func TestPageToken(t *testing.T) {
+	item := &pageItem{Number: 7}
+	item.ID[0] = 1
+
+	tests := []struct {
+		name   string
+		keys   bson.D
+		values bson.A
+	}{
+		{name: "field and _id", keys: bson.D{{Key: "number", Value: -1}, {Key: "_id", Value: 1}}, values: bson.A{int32(7), item.ID}},
+		{name: "nil pointer", keys: bson.D{{Key: "address.city", Value: 1}, {Key: "_id", Value: 1}}, values: bson.A{nil, item.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := encodePageToken(tt.keys, item)
+			if err != nil {
+				t.Fatalf("Failed to encode token: %v", err)
+			}
+
+			values, err := decodePageToken(token, tt.keys)
+			if err != nil {
+				t.Fatalf("Failed to decode token: %v", err)
+			}
+			if !reflect.DeepEqual(values, tt.values) {
+				t.Fatalf("Expected values %v, got %v", tt.values, values)
+			}
+
+			if _, err := decodePageToken(token, bson.D{{Key: "_id", Value: 1}}); !errors.Is(err, ErrInvalidPageToken) {
+				t.Fatalf("Expected ErrInvalidPageToken for other sort keys, got %v", err)
+			}
+		})
+	}
+}
+
+// TestKeysetFilter tests the filter selecting the documents after or before the values of a token
+func TestKeysetFilter(t *testing.T) {
+	keys := bson.D{{Key: "number", Value: -1}, {Key: "_id", Value: 1}}
+	values := bson.A{7, "id"}
+
+	tests := []struct {
+		name      string
+		backward  bool
+		operators [2]string
+	}{
+		{name: "forward", backward: false, operators: [2]string{"$lt", "$gt"}},
+		{name: "backward", backward: true, operators: [2]string{"$gt", "$lt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "number", Value: bson.D{{Key: tt.operators[0], Value: 7}}}},
+				bson.D{{Key: "number", Value: 7}, {Key: "_id", Value: bson.D{{Key: tt.operators[1], Value: "id"}}}},
+			}}}
+			if got := keysetFilter(keys, values, tt.backward); !reflect.DeepEqual(got, expected) {
+				t.Fatalf("Expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package morm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// PopulatePath describes a field to populate together with options for the populated documents.
+// The field is a Go field name or a bson field name of the model, and its localField, foreignField,
+// justOne and count tags describe the reference as they do for plain field names.
+// Paths with options, and count fields, are looked up with a $lookup pipeline, which requires MongoDB 5.0 or later.
+//
+// Example:
+//
+//	qb.Find().Populate(morm.Path("Author").Select("name email").Populate(morm.Path("Company"))).Exec()
+type PopulatePath struct {
+	field    string
+	selected string
+	match    interface{}
+	sort     bson.D
+	limit    int64
+	populate []*PopulatePath
+}
+
+// Path starts the description of a field to populate.
+func Path(field string) *PopulatePath {
+	return &PopulatePath{field: field}
+}
+
+// Select sets the fields kept in the populated documents, separated by spaces.
+// Fields prefixed with "-" are excluded instead, e.g. "-password".
+func (p *PopulatePath) Select(fields string) *PopulatePath {
+	p.selected = fields
+	return p
+}
+
+// Match sets a filter the populated documents must match in addition to the reference.
+func (p *PopulatePath) Match(filter interface{}) *PopulatePath {
+	p.match = filter
+	return p
+}
+
+// Sort sets the sort order of the populated documents.
+func (p *PopulatePath) Sort(sort bson.D) *PopulatePath {
+	p.sort = sort
+	return p
+}
+
+// Limit sets the maximum number of documents populated for each document.
+func (p *PopulatePath) Limit(limit int64) *PopulatePath {
+	p.limit = limit
+	return p
+}
+
+// Populate populates references of the populated documents.
+// It accepts the same values as CollectQueryBuilder.Populate.
+func (p *PopulatePath) Populate(paths ...interface{}) *PopulatePath {
+	p.populate = appendPopulatePaths(p.populate, paths)
+	return p
+}
+
+// hasOptions reports whether the path needs a lookup pipeline in addition to its localField/foreignField join.
+func (p *PopulatePath) hasOptions() bool {
+	return p.selected != "" || p.match != nil || p.sort != nil || p.limit != 0 || len(p.populate) > 0
+}
+
+// projection returns the $project document of the selected fields, or nil if no fields were selected.
+// Inclusion projections keep the nested fields, which are populated by nested paths.
+func (p *PopulatePath) projection(nested []string) bson.D {
+	fields := strings.Fields(p.selected)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	projection := bson.D{}
+	include := false
+	for _, field := range fields {
+		if strings.HasPrefix(field, "-") {
+			projection = append(projection, bson.E{Key: field[1:], Value: 0})
+			continue
+		}
+		include = true
+		projection = append(projection, bson.E{Key: field, Value: 1})
+	}
+
+	if include {
+		for _, as := range nested {
+			if !containsString(fields, as) {
+				projection = append(projection, bson.E{Key: as, Value: 1})
+			}
+		}
+	}
+	return projection
+}
+
+// appendPopulatePaths adds strings, string slices and paths to the list of paths to populate.
+// Dotted strings such as "Author.Company" populate nested references. Empty strings are ignored.
+func appendPopulatePaths(list []*PopulatePath, paths []interface{}) []*PopulatePath {
+	for _, path := range paths {
+		switch path := path.(type) {
+		case string:
+			if populatePath := dottedPath(path); populatePath != nil {
+				list = append(list, populatePath)
+			}
+		case []string:
+			for _, field := range path {
+				if populatePath := dottedPath(field); populatePath != nil {
+					list = append(list, populatePath)
+				}
+			}
+		case *PopulatePath:
+			if path != nil && path.field != "" {
+				list = append(list, path)
+			}
+		}
+	}
+	return list
+}
+
+// mergePopulatePaths resolves the paths against the model and merges paths referring to the same field
+// by different names, e.g. "Author" and "author". The paths passed in are left untouched.
+func mergePopulatePaths(modelType reflect.Type, paths []*PopulatePath) ([]*PopulatePath, error) {
+	var merged []*PopulatePath
+	for _, path := range paths {
+		structField, err := populateField(modelType, path.field)
+		if err != nil {
+			return nil, err
+		}
+		canonical := *path
+		canonical.field = structField.Name
+		canonical.populate = append([]*PopulatePath(nil), path.populate...)
+		merged = mergePopulatePath(merged, &canonical)
+	}
+	return merged, nil
+}
+
+// mergePopulatePath adds a path to the list. A path for a field already in the list is merged into it,
+// so the field is looked up only once: the options it sets replace the existing ones and its nested
+// paths are added.
+func mergePopulatePath(list []*PopulatePath, path *PopulatePath) []*PopulatePath {
+	for _, existing := range list {
+		if existing.field != path.field {
+			continue
+		}
+		if path.selected != "" {
+			existing.selected = path.selected
+		}
+		if path.match != nil {
+			existing.match = path.match
+		}
+		if path.sort != nil {
+			existing.sort = path.sort
+		}
+		if path.limit != 0 {
+			existing.limit = path.limit
+		}
+		existing.populate = append(existing.populate, path.populate...)
+		return list
+	}
+	return append(list, path)
+}
+
+// dottedPath converts "Author.Company" into a path for Author populating Company.
+func dottedPath(field string) *PopulatePath {
+	field = strings.TrimSpace(field)
+	if field == "" {
+		return nil
+	}
+
+	root, rest, nested := strings.Cut(field, ".")
+	path := Path(root)
+	if nested {
+		if child := dottedPath(rest); child != nil {
+			path.populate = append(path.populate, child)
+		}
+	}
+	return path
+}
+
+// populateField returns the struct field a populate path refers to, by Go name or bson name.
+func populateField(modelType reflect.Type, field string) (reflect.StructField, error) {
+	if structField, ok := modelType.FieldByName(field); ok {
+		return structField, nil
+	}
+	if structField, ok := fieldByBSONName(modelType, field); ok {
+		return structField, nil
+	}
+	return reflect.StructField{}, fmt.Errorf("%w %q in %s", ErrUnknownField, field, modelType.Name())
+}
+
+// containsString reports whether the list contains the value.
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
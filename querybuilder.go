@@ -1,6 +1,7 @@
 package morm
 
 import (
+	"context"
 	"reflect"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -38,6 +39,21 @@ func (qb *CollectQueryBuilder) Limit(n int64) *CollectQueryBuilder {
 	return qb
 }
 
+// BatchSize sets the number of documents the server returns in each batch of the query's cursor.
+//
+// Parameters:
+//   - n: The number of documents per batch.
+//
+// Example:
+//
+//	cursor, err := qb.Find(bson.M{}).BatchSize(500).Cursor(ctx)
+//
+// This method is commonly used with Cursor to trade round-trips for memory on large result sets.
+func (qb *CollectQueryBuilder) BatchSize(n int32) *CollectQueryBuilder {
+	qb.batchSize = n
+	return qb
+}
+
 // Projection sets the projection fields for the MongoDB query.
 // It specifies the fields to include or exclude from the query result.
 //
@@ -71,18 +87,33 @@ func (qb *CollectQueryBuilder) Sort(sort bson.D) *CollectQueryBuilder {
 }
 
 // Populate sets the fields to populate in the MongoDB query result.
-// It takes a slice of strings representing the field names to populate.
+// It takes field names, slices of field names or paths built with Path. Field names can be Go or bson
+// field names, and dotted names such as "Author.Company" populate references of populated documents.
 //
 // Example:
 //
 //	qb.Populate([]string{"Author", "Comments"})
+//	qb.Populate(morm.Path("Author").Select("name email").Match(bson.M{"active": true}).Populate(morm.Path("Company")))
 //
 // This method is commonly used to specify fields that are references to other collections
 // and need to be populated with their actual values in the query result.
 //
 // Note: Ensure that the provided field names are valid and exist in the MongoDB documents.
-func (qb *CollectQueryBuilder) Populate(fields []string) *CollectQueryBuilder {
-	qb.popFields = fields
+func (qb *CollectQueryBuilder) Populate(paths ...interface{}) *CollectQueryBuilder {
+	qb.popPaths = appendPopulatePaths(qb.popPaths, paths)
+	return qb
+}
+
+// WithContext sets the context.Context used by every operation run through the builder.
+// A context passed directly to an operation takes precedence over the builder's context.
+//
+// Example:
+//
+//	res, err := qb.WithContext(r.Context()).Find(bson.M{"active": true}).Exec()
+//
+// This method is useful for propagating request cancellation and deadlines to the driver.
+func (qb *CollectQueryBuilder) WithContext(ctx context.Context) *CollectQueryBuilder {
+	qb.ctx = ctx
 	return qb
 }
 
@@ -105,10 +136,14 @@ func (qb *CollectQueryBuilder) Populate(fields []string) *CollectQueryBuilder {
 // The type of 'result' should be a pointer to the model struct representing the collection documents.
 // The actual operation performed depends on the query method set using the Method() method.
 func (qb *CollectQueryBuilder) Exec() (interface{}, error) {
-	result := reflect.New(qb.c.modelType.Elem()).Interface()
+	return qb.ExecContext(qb.getContext())
+}
 
+// ExecContext executes the MongoDB query like Exec, using ctx for the query, cursor iteration and populate lookups.
+func (qb *CollectQueryBuilder) ExecContext(ctx context.Context) (interface{}, error) {
 	if qb.method == "findone" {
-		res, err := findone(qb, result)
+		result := reflect.New(qb.c.modelType.Elem()).Interface()
+		res, err := findone(ctx, qb, result)
 		if err != nil {
 			return nil, err
 		}
@@ -116,10 +151,24 @@ func (qb *CollectQueryBuilder) Exec() (interface{}, error) {
 	}
 
 	//Find
-	res, err := find(qb, result)
+	res, err := find(ctx, qb, func() interface{} {
+		return reflect.New(qb.c.modelType.Elem()).Interface()
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return res, nil
 }
+
+// getContext returns the first provided context, falling back to the builder's context and
+// finally to context.Background().
+func (qb *CollectQueryBuilder) getContext(ctx ...context.Context) context.Context {
+	if len(ctx) > 0 && ctx[0] != nil {
+		return ctx[0]
+	}
+	if qb.ctx != nil {
+		return qb.ctx
+	}
+	return context.Background()
+}
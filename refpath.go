@@ -0,0 +1,230 @@
+package morm
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// populateRefPaths populates the fields with a refPath tag, whose referenced collection depends on each document.
+// The refPath tag names the field holding the kind of the referenced document, e.g. "post" or "comment",
+// and the connection's NamingStrategy maps it to a collection, e.g. "posts".
+//
+// As a $lookup can only read a fixed collection, the documents are grouped by their referenced collection
+// and each collection is queried once with $in. The related documents are then stitched into the documents,
+// so the field is typically an interface{}, bson.Raw or []bson.Raw field.
+// The path's Select and Match options apply to the query, and its Sort and Limit to the related documents of
+// each document. Paths populating nested paths are rejected by populateStages, since the model of the related
+// documents is not known.
+//
+// Example:
+//
+//	type Activity struct {
+//	  TargetID   primitive.ObjectID `bson:"targetId"`
+//	  TargetType string             `bson:"targetType"`
+//	  Target     bson.Raw           `bson:"target" refPath:"targetType" localField:"targetId" foreignField:"_id" justOne:"true"`
+//	}
+//
+// Parameters:
+//   - ctx: The context.Context used for the queries.
+//   - modelType: The struct type of the documents being populated.
+//   - paths: The paths to populate. Paths without a refPath tag are skipped.
+//   - docs: The documents to populate.
+//
+// Returns:
+//   - []bson.Raw: The populated documents.
+//   - error: An error if a path does not exist in the model or a query failed.
+func (qb *CollectQueryBuilder) populateRefPaths(ctx context.Context, modelType reflect.Type, paths []*PopulatePath, docs []bson.Raw) ([]bson.Raw, error) {
+	merged, err := mergePopulatePaths(modelType, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range merged {
+		tags, err := getTags(modelType, path.field)
+		if err != nil {
+			return nil, err
+		}
+		if tags["refPath"] == "" {
+			continue
+		}
+
+		docs, err = qb.populateRefPath(ctx, path, tags, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return docs, nil
+}
+
+// populateRefPath populates a single refPath field of the documents.
+func (qb *CollectQueryBuilder) populateRefPath(ctx context.Context, path *PopulatePath, tags map[string]string, docs []bson.Raw) ([]bson.Raw, error) {
+	localField := tags["localField"]
+	foreignField := tags["foreignField"]
+	if foreignField == "" {
+		foreignField = "_id"
+	}
+
+	// Group the references by the collection named by each document's discriminator
+	collections := make([]string, len(docs))
+	references := make(map[string]bson.A)
+	for i, doc := range docs {
+		kind, ok := lookupPath(doc, tags["refPath"]).StringValueOK()
+		if !ok || kind == "" {
+			continue
+		}
+		collections[i] = qb.c.db.kindCollection(kind)
+		for _, value := range rawValues(lookupPath(doc, localField)) {
+			references[collections[i]] = append(references[collections[i]], value)
+		}
+	}
+
+	// Query each referenced collection once
+	related := make(map[string]map[string][]referencedDoc)
+	for collection, values := range references {
+		byKey, err := qb.findReferenced(ctx, path, collection, foreignField, values)
+		if err != nil {
+			return nil, err
+		}
+		related[collection] = byKey
+	}
+
+	// Stitch the related documents into the documents
+	populated := make([]bson.Raw, len(docs))
+	for i, doc := range docs {
+		var referenced []referencedDoc
+		if collections[i] != "" {
+			for _, value := range rawValues(lookupPath(doc, localField)) {
+				referenced = append(referenced, related[collections[i]][rawKey(value)]...)
+			}
+		}
+		// The related documents of different references are only in the query's order within each reference
+		if path.sort != nil {
+			sort.SliceStable(referenced, func(a, b int) bool { return referenced[a].rank < referenced[b].rank })
+		}
+		if path.limit != 0 && int64(len(referenced)) > path.limit {
+			referenced = referenced[:path.limit]
+		}
+
+		matched := bson.A{}
+		for _, relatedDoc := range referenced {
+			matched = append(matched, relatedDoc.doc)
+		}
+
+		var value interface{} = matched
+		switch {
+		case tags["count"] == "true":
+			value = len(matched)
+		case tags["justOne"] == "true":
+			value = nil
+			if len(matched) > 0 {
+				value = matched[0]
+			}
+		}
+
+		var err error
+		populated[i], err = setRawField(doc, tags["bson"], value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return populated, nil
+}
+
+// referencedDoc is a document related through a refPath field and its rank in the query's sort order.
+type referencedDoc struct {
+	doc  bson.Raw
+	rank int
+}
+
+// findReferenced queries the documents of a collection whose foreign field matches one of the values,
+// applying the path's match, sort and select options, and indexes them by the foreign field.
+func (qb *CollectQueryBuilder) findReferenced(ctx context.Context, path *PopulatePath, collection string, foreignField string, values bson.A) (map[string][]referencedDoc, error) {
+	filter := interface{}(bson.D{{Key: foreignField, Value: bson.D{{Key: "$in", Value: values}}}})
+	if path.match != nil {
+		filter = bson.D{{Key: "$and", Value: bson.A{filter, path.match}}}
+	}
+
+	options := options.Find()
+	if path.sort != nil {
+		options.SetSort(path.sort)
+	}
+	// Keep the foreign field, which is needed to stitch the documents
+	if projection := path.projection([]string{foreignField}); projection != nil {
+		options.SetProjection(projection)
+	}
+
+	cursor, err := qb.c.collection.Database().Collection(collection).Find(ctx, filter, options)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	byKey := make(map[string][]referencedDoc)
+	for rank := 0; cursor.Next(ctx); rank++ {
+		doc := append(bson.Raw(nil), cursor.Current...)
+		for _, value := range rawValues(lookupPath(doc, foreignField)) {
+			key := rawKey(value)
+			byKey[key] = append(byKey[key], referencedDoc{doc: doc, rank: rank})
+		}
+	}
+
+	return byKey, cursor.Err()
+}
+
+// lookupPath returns the value at a dotted path of the document, or a zero RawValue if it does not exist.
+func lookupPath(doc bson.Raw, path string) bson.RawValue {
+	if path == "" {
+		return bson.RawValue{}
+	}
+	return doc.Lookup(strings.Split(path, ".")...)
+}
+
+// rawValues returns the elements of an array value, or the value itself. Missing and null values are skipped.
+func rawValues(value bson.RawValue) []bson.RawValue {
+	switch value.Type {
+	case 0, bsontype.Null:
+		return nil
+	case bsontype.Array:
+		elems, err := value.Array().Values()
+		if err != nil {
+			return nil
+		}
+		var values []bson.RawValue
+		for _, elem := range elems {
+			values = append(values, rawValues(elem)...)
+		}
+		return values
+	}
+	return []bson.RawValue{value}
+}
+
+// rawKey returns a map key identifying a value by its type and bytes.
+func rawKey(value bson.RawValue) string {
+	return string(value.Type) + string(value.Value)
+}
+
+// setRawField returns a copy of the document with the field set to the value.
+func setRawField(doc bson.Raw, key string, value interface{}) (bson.Raw, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(bson.D, 0, len(elems)+1)
+	for _, elem := range elems {
+		if elem.Key() != key {
+			fields = append(fields, bson.E{Key: elem.Key(), Value: elem.Value()})
+		}
+	}
+	fields = append(fields, bson.E{Key: key, Value: value})
+
+	return bson.Marshal(fields)
+}
@@ -0,0 +1,90 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestFindInBatches tests that batches walk every document and can be resumed after a failure
+func TestFindInBatches(t *testing.T) {
+	seedOrders(t, 5)
+
+	errStop := errors.New("stop")
+	var seen []int
+	err := newOrders(t).Find().FindInBatches(context.Background(), 2, func(batch []*Order, n int) error {
+		if n == 2 {
+			return errStop
+		}
+		for _, order := range batch {
+			seen = append(seen, order.Number)
+		}
+		return nil
+	})
+
+	var batchErr *morm.BatchError
+	if !errors.As(err, &batchErr) || !errors.Is(err, errStop) {
+		t.Fatalf("Expected a BatchError wrapping the callback error, got %v", err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("Expected the first batch of 2 orders before the failure, got %v", seen)
+	}
+
+	batches := 0
+	err = newOrders(t).Find().ResumeAfter(batchErr.LastID).FindInBatches(context.Background(), 2, func(batch []*Order, n int) error {
+		batches = n
+		for _, order := range batch {
+			seen = append(seen, order.Number)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Resumed FindInBatches returned an error: %v", err)
+	}
+
+	if len(seen) != 5 || batches != 2 {
+		t.Fatalf("Expected the remaining 3 orders in 2 batches, got %v in %d batches", seen, batches)
+	}
+}
+
+// TestFindInBatchesProjection tests that batches keep _id when the projection excludes it
+func TestFindInBatchesProjection(t *testing.T) {
+	seedOrders(t, 5)
+
+	var seen []*Order
+	err := newOrders(t).Find().Projection(bson.D{{Key: "_id", Value: 0}}).FindInBatches(context.Background(), 2, func(batch []*Order, n int) error {
+		if n > 3 {
+			return errors.New("too many batches")
+		}
+		seen = append(seen, batch...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("FindInBatches returned an error: %v", err)
+	}
+	if len(seen) != 5 || seen[0].ID.IsZero() {
+		t.Fatalf("Expected 5 orders with their _id, got %d", len(seen))
+	}
+}
+
+// TestFindInBatchesRejectsSort tests that FindInBatches refuses an order of its own
+func TestFindInBatchesRejectsSort(t *testing.T) {
+	db := connectLazy(t, "lazy-batches")
+
+	collect, err := db.Collection("test_collection", &TestModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	called := false
+	err = collect.Find().Sort(bson.D{{Key: "field1", Value: 1}}).FindInBatches(context.Background(), 10, func(batch []interface{}, n int) error {
+		called = true
+		return nil
+	})
+	if err == nil || called {
+		t.Fatal("Expected FindInBatches with Sort to fail without querying")
+	}
+}
@@ -0,0 +1,140 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestBulkPrepare tests that failing operations are reported by their index without being sent
+func TestBulkPrepare(t *testing.T) {
+	tickets := newTickets(t, "lazy-bulk-prepare")
+	tickets.Query().Pre(morm.HookDeleteMany, func(ctx context.Context, op *morm.Operation) error {
+		return errRejected
+	})
+
+	bulk := tickets.Bulk().
+		InsertOne(&Ticket{}).
+		UpdateOne(bson.M{"code": "a"}, morm.Set("status", "pending")).
+		UpsertOne(bson.M{"code": "b"}, morm.Set("missing", 1)).
+		ReplaceOne(bson.M{"code": "c"}, &Ticket{Code: "c", Status: "pending"}).
+		DeleteMany(bson.M{})
+	if bulk.Len() != 5 {
+		t.Fatalf("Expected 5 operations, got %d", bulk.Len())
+	}
+
+	res, err := bulk.Execute(context.Background(), morm.Ordered(false))
+
+	var bulkErr *morm.BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("Expected a *BulkError, got %v", err)
+	}
+	if len(bulkErr.Errors) != 5 {
+		t.Fatalf("Expected 5 failed operations, got %v", bulkErr.Errors)
+	}
+	var validationErr *morm.ValidationError
+	for _, i := range []int{0, 1, 3} {
+		if !errors.As(bulkErr.Errors[i], &validationErr) {
+			t.Fatalf("Expected a validation error at index %d, got %v", i, bulkErr.Errors[i])
+		}
+	}
+	if !errors.Is(bulkErr.Errors[4], errRejected) {
+		t.Fatalf("Expected the rejected delete at index 4, got %v", bulkErr.Errors[4])
+	}
+	if res == nil || res.InsertedCount != 0 || res.DeletedCount != 0 {
+		t.Fatalf("Expected an empty result, got %+v", res)
+	}
+
+	// An ordered bulk write stops at the first failed operation
+	next := &Ticket{Code: "d"}
+	_, err = tickets.Bulk().InsertOne(&Ticket{}).InsertOne(next).Execute(context.Background())
+	if !errors.As(err, &bulkErr) || len(bulkErr.Errors) != 1 {
+		t.Fatalf("Expected only the first operation to fail, got %v", err)
+	}
+	if !next.ID.IsZero() {
+		t.Fatal("Expected operations after the failure not to be prepared")
+	}
+}
+
+// TestBulk tests mixed bulk writes against a running server
+func TestBulk(t *testing.T) {
+	if _, err := morm.Connect("mongodb://localhost:27017", "test_db"); err != nil {
+		t.Skipf("MongoDB is not available: %v", err)
+	}
+
+	tickets, err := morm.For[Ticket]("bulk_tickets")
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+	tickets.Query().DeleteMany(bson.M{})
+	tickets.Query().Timestamps(morm.TimestampPolicy{CreatedAt: "created", UpdatedAt: "updated"})
+
+	if _, err := tickets.CreateMany(context.Background(), []*Ticket{{Code: "a"}, {Code: "b"}, {Code: "c"}}); err != nil {
+		t.Fatalf("Failed to create tickets: %v", err)
+	}
+
+	res, err := tickets.Bulk().
+		InsertOne(&Ticket{Code: "d"}).
+		UpdateOne(bson.M{"code": "a"}, morm.Set("status", "closed")).
+		UpdateMany(bson.M{"code": bson.M{"$in": bson.A{"a", "b"}}}, morm.Set("priority", 1)).
+		UpsertOne(bson.M{"code": "e"}, morm.Set("status", "open")).
+		ReplaceOne(bson.M{"code": "b"}, &Ticket{Code: "b2"}).
+		DeleteOne(bson.M{"code": "c"}).
+		Execute(context.Background(), morm.ChunkSize(2))
+	if err != nil {
+		t.Fatalf("Failed to execute bulk write: %v", err)
+	}
+
+	if res.InsertedCount != 1 || res.MatchedCount != 4 || res.DeletedCount != 1 || res.UpsertedCount != 1 {
+		t.Fatalf("Unexpected counts %+v", res)
+	}
+	if _, ok := res.UpsertedIDs[3]; !ok {
+		t.Fatalf("Expected the upserted ID at index 3, got %v", res.UpsertedIDs)
+	}
+
+	upserted, err := tickets.Query().Find(bson.M{"code": "e"}).ExecMap()
+	if err != nil || len(upserted) != 1 {
+		t.Fatalf("Failed to find upserted ticket: %v", err)
+	}
+	if _, ok := upserted[0]["created"]; !ok {
+		t.Fatalf("Expected the upsert to set the created timestamp, got %v", upserted[0])
+	}
+
+	replaced, err := tickets.FindOne(bson.M{"code": "b2"}).Exec()
+	if err != nil || replaced == nil || replaced.Status != "open" || replaced.Priority != 3 {
+		t.Fatalf("Expected the replacement to get its defaults, got %+v (%v)", replaced, err)
+	}
+}
+
+// TestBulkReplaceKeepsCreatedAt tests that a replacement keeps the created-at timestamp of the stored document
+func TestBulkReplaceKeepsCreatedAt(t *testing.T) {
+	if _, err := morm.Connect("mongodb://localhost:27017", "test_db"); err != nil {
+		t.Skipf("MongoDB is not available: %v", err)
+	}
+
+	models, err := morm.For[TestModel]("bulk_replace_models")
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+	models.Query().DeleteMany(bson.M{})
+
+	stored := &TestModel{Field1: "a"}
+	if _, err := models.Create(stored); err != nil {
+		t.Fatalf("Failed to create model: %v", err)
+	}
+
+	if _, err := models.Bulk().ReplaceOne(bson.M{"_id": stored.ID}, &TestModel{Field1: "b"}).Execute(context.Background()); err != nil {
+		t.Fatalf("Failed to execute bulk write: %v", err)
+	}
+
+	replaced, err := models.FindOne(bson.M{"_id": stored.ID}).Exec()
+	if err != nil || replaced == nil || replaced.Field1 != "b" {
+		t.Fatalf("Expected the document to be replaced, got %+v (%v)", replaced, err)
+	}
+	if !replaced.CreatedAt.Equal(stored.CreatedAt) {
+		t.Fatalf("Expected createdAt %v to be kept, got %v", stored.CreatedAt, replaced.CreatedAt)
+	}
+}
@@ -0,0 +1,72 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestCount tests counting, estimating and checking for documents
+func TestCount(t *testing.T) {
+	seedOrders(t, 5)
+
+	n, err := newOrders(t).Find().Where("number").Gte(2).Count()
+	if err != nil {
+		t.Fatalf("Count returned an error: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("Expected 3 orders, got %d", n)
+	}
+
+	n, err = newOrders(t).Find().Skip(1).Limit(2).Count()
+	if err != nil || n != 2 {
+		t.Fatalf("Expected a count of 2 within skip and limit, got %d, %v", n, err)
+	}
+
+	orders := newOrders(t)
+	if n, err := orders.EstimatedCount(); err != nil || n != 5 {
+		t.Fatalf("Expected an estimated count of 5, got %d, %v", n, err)
+	}
+
+	exists, err := orders.DocumentExists(bson.M{"number": 4})
+	if err != nil || !exists {
+		t.Fatalf("Expected order 4 to exist, got %v, %v", exists, err)
+	}
+	exists, err = orders.DocumentExists(bson.M{"number": 5})
+	if err != nil || exists {
+		t.Fatalf("Expected order 5 not to exist, got %v, %v", exists, err)
+	}
+}
+
+// TestCountHooks tests that counts run the count hooks with the builder's filter
+func TestCountHooks(t *testing.T) {
+	db := connectLazy(t, "lazy-count")
+
+	collect, err := db.Collection("test_collection", &TestModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	var filters []interface{}
+	collect.Pre(morm.HookCount, func(ctx context.Context, op *morm.Operation) error {
+		filters = append(filters, op.Filter)
+		return errRejected
+	})
+
+	if _, err := collect.Find(bson.M{"field2": 1}).Count(); !errors.Is(err, errRejected) {
+		t.Fatalf("Expected the count hook to abort Count, got %v", err)
+	}
+	if _, err := collect.DocumentExists(bson.M{"field1": "x"}); !errors.Is(err, errRejected) {
+		t.Fatalf("Expected the count hook to abort DocumentExists, got %v", err)
+	}
+
+	if len(filters) != 2 {
+		t.Fatalf("Expected the hook to run twice, got %d", len(filters))
+	}
+	if filter, ok := filters[1].(bson.M); !ok || filter["field1"] != "x" {
+		t.Fatalf("Expected DocumentExists to count with its filter, got %v", filters[1])
+	}
+}
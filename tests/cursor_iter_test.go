@@ -0,0 +1,28 @@
+//go:build go1.23
+
+package morm
+
+import (
+	"context"
+	"testing"
+)
+
+// TestFindAll tests that ranging over All yields every document
+func TestFindAll(t *testing.T) {
+	seedOrders(t, 3)
+
+	n := 0
+	for order, err := range newOrders(t).Find().BatchSize(1).All(context.Background()) {
+		if err != nil {
+			t.Fatalf("All yielded an error: %v", err)
+		}
+		if order == nil {
+			t.Fatal("All yielded a nil order")
+		}
+		n++
+	}
+
+	if n != 3 {
+		t.Fatalf("Expected 3 orders, got %d", n)
+	}
+}
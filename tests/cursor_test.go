@@ -0,0 +1,78 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestCursor tests that a typed cursor streams every document across batches with populated fields
+func TestCursor(t *testing.T) {
+	seedOrders(t, 5)
+
+	cursor, err := newOrders(t).Find().Sort(bson.D{{Key: "number", Value: 1}}).BatchSize(2).Populate("Customer").Cursor(context.Background())
+	if err != nil {
+		t.Fatalf("Cursor returned an error: %v", err)
+	}
+	defer cursor.Close(context.Background())
+
+	n := 0
+	for cursor.Next(context.Background()) {
+		order, err := cursor.Decode()
+		if err != nil {
+			t.Fatalf("Decode returned an error: %v", err)
+		}
+		if order.Number != n {
+			t.Errorf("Expected order %d, got %d", n, order.Number)
+		}
+		if order.Customer == nil {
+			t.Errorf("Expected order %d to be populated with its customer", order.Number)
+		}
+		n++
+	}
+
+	if err := cursor.Err(); err != nil {
+		t.Fatalf("Cursor failed: %v", err)
+	}
+	if n != 5 {
+		t.Fatalf("Expected 5 orders, got %d", n)
+	}
+}
+
+// TestCursorRunsFindHooks tests that opening a cursor runs the pre find hooks on the query's compiled filter
+// and returns their error without opening a server cursor
+func TestCursorRunsFindHooks(t *testing.T) {
+	db := connectLazy(t, "lazy-cursor")
+
+	collect, err := db.Collection("test_collection", &TestModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	var compiled interface{}
+	collect.Pre(morm.HookFind, func(ctx context.Context, op *morm.Operation) error {
+		compiled = op.Filter
+		return errRejected
+	})
+
+	cursor, err := collect.Find().Where("field2").Gt(1).BatchSize(10).Cursor(context.Background())
+	if !errors.Is(err, errRejected) || cursor != nil {
+		t.Fatalf("Expected the hook error without a cursor, got %v", err)
+	}
+
+	expected := bson.D{{Key: "field2", Value: bson.D{{Key: "$gt", Value: 1}}}}
+	if !reflect.DeepEqual(compiled, expected) {
+		t.Fatalf("Expected %v, got %v", expected, compiled)
+	}
+
+	// Unknown fields are reported before any hook runs
+	compiled = nil
+	_, err = collect.Find().Where("missing").Gt(1).Cursor(context.Background())
+	if !errors.Is(err, morm.ErrUnknownField) || compiled != nil {
+		t.Fatalf("Expected ErrUnknownField, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestWhereCompilesFilter tests that Where conditions are merged with the raw filter passed to Find
+func TestWhereCompilesFilter(t *testing.T) {
+	db := connectLazy(t, "lazy-filter-builder")
+
+	collect, err := db.Collection("test_collection", &TestModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	// Capture the compiled filter and stop before the query reaches the server
+	var compiled interface{}
+	collect.Pre(morm.HookFind, func(ctx context.Context, op *morm.Operation) error {
+		compiled = op.Filter
+		return errRejected
+	})
+
+	collect.Find().Where("field2").Gte(18).Lt(65).Where("field1").In("a", "b").Exec()
+	expected := bson.D{
+		{Key: "field2", Value: bson.D{{Key: "$gte", Value: 18}, {Key: "$lt", Value: 65}}},
+		{Key: "field1", Value: bson.D{{Key: "$in", Value: bson.A{"a", "b"}}}},
+	}
+	if !reflect.DeepEqual(compiled, expected) {
+		t.Fatalf("Expected %v, got %v", expected, compiled)
+	}
+
+	raw := bson.M{"field1": "a"}
+	other, _ := db.Collection("test_collection", &TestModel{})
+	other.Find(raw).Where("field2", 3).Or(bson.M{"field1": "x"}, bson.M{"field1": "y"}).Exec()
+	expected = bson.D{{Key: "$and", Value: bson.A{
+		raw,
+		bson.D{{Key: "field2", Value: bson.D{{Key: "$eq", Value: 3}}}},
+		bson.D{{Key: "$or", Value: bson.A{bson.M{"field1": "x"}, bson.M{"field1": "y"}}}},
+	}}}
+	if !reflect.DeepEqual(compiled, expected) {
+		t.Fatalf("Expected %v, got %v", expected, compiled)
+	}
+}
+
+// TestWhereUnknownField tests that Exec rejects fields that do not exist in the model
+func TestWhereUnknownField(t *testing.T) {
+	db := connectLazy(t, "lazy-filter-fields")
+
+	users, err := morm.For[TestModel]("test_collection", db)
+	if err != nil {
+		t.Fatalf("Failed to create typed collection: %v", err)
+	}
+
+	_, err = users.Find().Where("age").Gte(18).Exec()
+	if !errors.Is(err, morm.ErrUnknownField) {
+		t.Fatalf("Expected ErrUnknownField, got %v", err)
+	}
+
+	_, err = users.Find().Or(bson.M{"field1": "a"}, bson.M{"age": 18}).Exec()
+	if !errors.Is(err, morm.ErrUnknownField) {
+		t.Fatalf("Expected ErrUnknownField for a field inside Or, got %v", err)
+	}
+
+	_, err = users.FindOne(nil).Gte(18).Exec()
+	if err == nil {
+		t.Fatal("Expected an error for a condition without Where")
+	}
+}
+
+// TestWhereScopesWrites tests that Where conditions are merged with the filters of updates and deletes
+func TestWhereScopesWrites(t *testing.T) {
+	db := connectLazy(t, "lazy-filter-writes")
+
+	collect, err := db.Collection("test_collection", &TestModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	// Capture the compiled filters and stop before the writes reach the server
+	var compiled []interface{}
+	capture := func(ctx context.Context, op *morm.Operation) error {
+		compiled = append(compiled, op.Filter)
+		return errRejected
+	}
+	collect.Pre(morm.HookDeleteMany, capture).Pre(morm.HookUpdateOne, capture).Pre(morm.HookFindOneAndRemove, capture)
+
+	collect.Where("field2").Lt(18).DeleteMany(bson.M{})
+	collect.UpdateOne(bson.M{"field1": "a"}, bson.M{"$set": bson.M{"field1": "b"}})
+	collect.FindOneAndRemove(nil)
+
+	where := bson.D{{Key: "field2", Value: bson.D{{Key: "$lt", Value: 18}}}}
+	expected := []interface{}{
+		bson.D{{Key: "$and", Value: bson.A{bson.M{}, where}}},
+		bson.D{{Key: "$and", Value: bson.A{bson.M{"field1": "a"}, where}}},
+		where,
+	}
+	if !reflect.DeepEqual(compiled, expected) {
+		t.Fatalf("Expected %v, got %v", expected, compiled)
+	}
+}
@@ -0,0 +1,53 @@
+package morm
+
+import (
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestExecMap tests that ExecMap returns the documents as maps
+func TestExecMap(t *testing.T) {
+	seedOrders(t, 3)
+
+	docs, err := newOrders(t).Find().Sort(bson.D{{Key: "number", Value: 1}}).Projection(bson.D{{Key: "number", Value: 1}}).ExecMap()
+	if err != nil {
+		t.Fatalf("ExecMap returned an error: %v", err)
+	}
+
+	if len(docs) != 3 {
+		t.Fatalf("Expected 3 documents, got %d", len(docs))
+	}
+	for i, doc := range docs {
+		if doc["number"] != int64(i) && doc["number"] != int32(i) {
+			t.Errorf("Expected number %d, got %v", i, doc["number"])
+		}
+		if _, ok := doc["customerId"]; ok {
+			t.Errorf("Expected customerId to be excluded by the projection, got %v", doc)
+		}
+	}
+}
+
+// TestExecIndex tests that ExecIndex keys the documents by a field
+func TestExecIndex(t *testing.T) {
+	seedOrders(t, 3)
+
+	byNumber, err := morm.ExecIndex[int](newOrders(t).Find(), "number")
+	if err != nil {
+		t.Fatalf("ExecIndex returned an error: %v", err)
+	}
+
+	if len(byNumber) != 3 {
+		t.Fatalf("Expected 3 orders, got %d", len(byNumber))
+	}
+	for number, order := range byNumber {
+		if order.Number != number {
+			t.Errorf("Expected order %d under key %d", order.Number, number)
+		}
+	}
+
+	if _, err := morm.ExecIndex[string](newOrders(t).Find(), "missing"); err == nil {
+		t.Fatal("Expected an error for an unknown field")
+	}
+}
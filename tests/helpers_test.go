@@ -0,0 +1,25 @@
+package morm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devsamahd/morm"
+)
+
+// connectLazy registers a connection to an unreachable server under name without pinging it.
+// Operations that fail before reaching the server can be tested with it.
+// The connection is disconnected and unregistered when the test ends.
+func connectLazy(t *testing.T, name string) *morm.DB {
+	t.Helper()
+
+	db, err := morm.ConnectNamed(name, "mongodb://localhost:1", "test_db", morm.WithPingOnConnect(false))
+	if err != nil {
+		t.Fatalf("Failed to create connection: %v", err)
+	}
+	t.Cleanup(func() {
+		morm.Unregister(name)
+		db.Disconnect(context.Background())
+	})
+	return db
+}
@@ -0,0 +1,141 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type HookedModel struct {
+	morm.Model `bson:",inline"`
+	Name       string `bson:"name"`
+}
+
+var errRejected = errors.New("rejected")
+
+// beforeUpdates counts the calls of HookedModel.BeforeUpdate and deleteFilters records the filters passed to
+// HookedModel.BeforeDelete.
+var (
+	beforeUpdates int
+	deleteFilters []interface{}
+)
+
+// BeforeCreate implements morm.BeforeCreator
+func (m *HookedModel) BeforeCreate(ctx context.Context) error {
+	if m.Name == "" {
+		return errRejected
+	}
+	return nil
+}
+
+// BeforeUpdate implements morm.BeforeUpdater
+func (m *HookedModel) BeforeUpdate(ctx context.Context) error {
+	beforeUpdates++
+	if m.Name == "" {
+		return errRejected
+	}
+	return nil
+}
+
+// BeforeDelete implements morm.BeforeDeleter
+func (m *HookedModel) BeforeDelete(ctx context.Context, filter interface{}) error {
+	deleteFilters = append(deleteFilters, filter)
+	return errRejected
+}
+
+// TestModelUpdateHooks tests that BeforeUpdate runs for model updates but not for operator documents
+func TestModelUpdateHooks(t *testing.T) {
+	db := connectLazy(t, "lazy-model-hooks")
+
+	collect, err := db.Collection("hooked", &HookedModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	beforeUpdates = 0
+	if err := collect.UpdateOne(bson.M{"name": "a"}, &HookedModel{}); !errors.Is(err, errRejected) {
+		t.Fatalf("Expected BeforeUpdate to reject the model update, got %v", err)
+	}
+
+	// The unknown field stops the operator update after its model hooks, before it is sent
+	if err := collect.UpdateOne(bson.M{"name": "a"}, morm.Set("missing", 1)); !errors.Is(err, morm.ErrUnknownField) {
+		t.Fatalf("Expected ErrUnknownField from the operator update, got %v", err)
+	}
+
+	if beforeUpdates != 1 {
+		t.Fatalf("Expected BeforeUpdate to run only for the model update, got %d calls", beforeUpdates)
+	}
+}
+
+// TestModelDeleteHooks tests that BeforeDelete runs with the filter of every delete and aborts it
+func TestModelDeleteHooks(t *testing.T) {
+	db := connectLazy(t, "lazy-delete-hooks")
+
+	collect, err := db.Collection("hooked", &HookedModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	deleteFilters = nil
+	filter := bson.M{"name": "a"}
+	if err := collect.Delete(filter); !errors.Is(err, errRejected) {
+		t.Fatalf("Expected BeforeDelete to abort Delete, got %v", err)
+	}
+	if _, err := collect.DeleteMany(filter); !errors.Is(err, errRejected) {
+		t.Fatalf("Expected BeforeDelete to abort DeleteMany, got %v", err)
+	}
+	if _, err := collect.FindOneAndRemove(filter); !errors.Is(err, errRejected) {
+		t.Fatalf("Expected BeforeDelete to abort FindOneAndRemove, got %v", err)
+	}
+	if _, err := collect.Bulk().DeleteOne(filter).Execute(context.Background()); !errors.Is(err, errRejected) {
+		t.Fatalf("Expected BeforeDelete to abort the bulk delete, got %v", err)
+	}
+
+	if len(deleteFilters) != 4 || !reflect.DeepEqual(deleteFilters[0], filter) {
+		t.Fatalf("Expected BeforeDelete to run with the filter of the 4 deletes, got %v", deleteFilters)
+	}
+}
+
+// TestPreHooksAbort tests that pre hooks run before the operation and abort it on error
+func TestPreHooksAbort(t *testing.T) {
+	db := connectLazy(t, "lazy-hooks")
+
+	collect, err := db.Collection("hooked", &HookedModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	var seen []string
+	collect.Pre(morm.HookFind, func(ctx context.Context, op *morm.Operation) error {
+		seen = append(seen, op.Name)
+		return errRejected
+	})
+	collect.Pre(morm.HookSave, func(ctx context.Context, op *morm.Operation) error {
+		seen = append(seen, op.Name)
+		return nil
+	})
+
+	// Hooks are shared by every builder of the same collection and model
+	other, err := db.Collection("hooked", &HookedModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	_, err = other.Find(bson.M{"name": "x"}).Exec()
+	if !errors.Is(err, errRejected) {
+		t.Fatalf("Expected the pre find hook to abort Exec, got %v", err)
+	}
+
+	_, err = other.Create(&HookedModel{})
+	if !errors.Is(err, errRejected) {
+		t.Fatalf("Expected BeforeCreate to abort Create, got %v", err)
+	}
+
+	if len(seen) != 2 || seen[0] != morm.HookFind || seen[1] != morm.HookSave {
+		t.Fatalf("Expected find and save hooks to run, got %v", seen)
+	}
+}
@@ -0,0 +1,109 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type Ticket struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	Code     string             `bson:"code" morm:"required"`
+	Status   string             `bson:"status" morm:"default=open,enum=open|closed"`
+	Priority int                `bson:"priority" morm:"default=3"`
+	Timeout  time.Duration      `bson:"timeout" morm:"default=1m30s"`
+	Notify   *bool              `bson:"notify" morm:"default=true"`
+}
+
+func newTickets(t *testing.T, name string) *morm.TypedCollection[Ticket] {
+	t.Helper()
+
+	tickets, err := morm.For[Ticket]("tickets", connectLazy(t, name))
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+	return tickets
+}
+
+// TestCreateManyValidation tests that invalid documents are reported by index without being inserted
+func TestCreateManyValidation(t *testing.T) {
+	tickets := newTickets(t, "lazy-createmany-validation")
+
+	docs := []*Ticket{{}, {Status: "pending"}, {}}
+	ids, err := tickets.CreateMany(context.Background(), docs, morm.Ordered(false))
+
+	var bulkErr *morm.BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("Expected a *BulkError, got %v", err)
+	}
+	if len(bulkErr.Errors) != 3 {
+		t.Fatalf("Expected 3 failed documents, got %v", bulkErr.Errors)
+	}
+	var validationErr *morm.ValidationError
+	if !errors.As(bulkErr.Errors[1], &validationErr) || !errors.As(err, &validationErr) {
+		t.Fatalf("Expected validation errors, got %v", bulkErr.Errors[1])
+	}
+	if len(ids) != 3 || !ids[0].IsZero() {
+		t.Fatalf("Expected no inserted IDs, got %v", ids)
+	}
+
+	// An ordered insert stops at the first invalid document
+	docs = []*Ticket{{}, {}}
+	_, err = tickets.CreateMany(context.Background(), docs)
+	if !errors.As(err, &bulkErr) || len(bulkErr.Errors) != 1 || bulkErr.Errors[0] == nil {
+		t.Fatalf("Expected only the first document to fail, got %v", err)
+	}
+	if !docs[1].ID.IsZero() {
+		t.Fatal("Expected documents after the failure not to be prepared")
+	}
+}
+
+// TestCreateMany tests chunked inserts and duplicate key errors against a running server
+func TestCreateMany(t *testing.T) {
+	if _, err := morm.Connect("mongodb://localhost:27017", "test_db"); err != nil {
+		t.Skipf("MongoDB is not available: %v", err)
+	}
+
+	tickets, err := morm.For[Ticket]("createmany_tickets")
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+	tickets.Query().DeleteMany(bson.M{})
+
+	existing := &Ticket{Code: "dup"}
+	if _, err := tickets.Create(existing); err != nil {
+		t.Fatalf("Failed to create ticket: %v", err)
+	}
+
+	docs := []*Ticket{{Code: "a"}, {ID: existing.ID, Code: "dup"}, {Code: "b"}, {Code: "c"}, {}}
+	ids, err := tickets.CreateMany(context.Background(), docs, morm.Ordered(false), morm.ChunkSize(2))
+
+	var bulkErr *morm.BulkError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Errors) != 2 {
+		t.Fatalf("Expected 2 failed documents, got %v", err)
+	}
+	if !mongo.IsDuplicateKeyError(bulkErr.Errors[1]) {
+		t.Fatalf("Expected a duplicate key error at index 1, got %v", bulkErr.Errors[1])
+	}
+	for _, i := range []int{0, 2, 3} {
+		if ids[i] != docs[i].ID {
+			t.Fatalf("Expected ID of document %d, got %v", i, ids[i])
+		}
+	}
+
+	stored, err := tickets.FindOne(bson.M{"_id": docs[0].ID}).Exec()
+	if err != nil || stored == nil || stored.Status != "open" || stored.Priority != 3 || stored.Timeout != 90*time.Second {
+		t.Fatalf("Expected the ticket to be stored with its defaults, got %+v (%v)", stored, err)
+	}
+
+	n, err := tickets.Query().Count()
+	if err != nil || n != 4 {
+		t.Fatalf("Expected 4 tickets, got %d (%v)", n, err)
+	}
+}
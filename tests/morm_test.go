@@ -2,6 +2,7 @@ package morm
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/devsamahd/morm"
@@ -58,3 +59,43 @@ func TestCollection(t *testing.T) {
 		t.Fatal("Collection should not be nil")
 	}
 }
+
+// TestUse tests the named connection registry
+func TestUse(t *testing.T) {
+	uri := "mongodb://localhost:27017"
+	db, err := morm.ConnectNamed("analytics", uri, "test_analytics_db")
+	if err != nil {
+		t.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	if morm.Use("analytics") != db {
+		t.Fatal("Use should return the connection registered under the given name")
+	}
+
+	if morm.Use("analytics") == morm.MongoDBInstance {
+		t.Fatal("Named connections should not replace the default connection")
+	}
+
+	_, err = morm.Use("missing").Collection("test_collection", &TestModel{})
+	if !errors.Is(err, morm.ErrNotConnected) {
+		t.Fatalf("Expected ErrNotConnected for an unknown connection, got %v", err)
+	}
+}
+
+// TestUnregister tests that Unregister removes a named connection without disconnecting it
+func TestUnregister(t *testing.T) {
+	db, err := morm.ConnectNamed("unregistered", "mongodb://localhost:1", "test_db", morm.WithPingOnConnect(false))
+	if err != nil {
+		t.Fatalf("Failed to create connection: %v", err)
+	}
+	defer db.Disconnect(context.Background())
+
+	morm.Unregister("unregistered")
+	if morm.Use("unregistered") != nil {
+		t.Fatal("Expected Unregister to remove the connection")
+	}
+
+	if _, err := db.Collection("test_collection", &TestModel{}); err != nil {
+		t.Fatalf("Expected the unregistered connection to stay usable, got %v", err)
+	}
+}
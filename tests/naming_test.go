@@ -0,0 +1,59 @@
+package morm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/devsamahd/morm"
+)
+
+// TestNamingStrategies tests that each strategy maps populated field names to the expected collections
+func TestNamingStrategies(t *testing.T) {
+	explicit := morm.Explicit(map[string]string{"Author": "users"}, morm.EnglishPlural)
+
+	tests := []struct {
+		strategy morm.NamingStrategy
+		name     string
+		want     string
+	}{
+		{morm.LowerPlural, "Category", "categorys"},
+		{morm.EnglishPlural, "Category", "categories"},
+		{morm.EnglishPlural, "Day", "days"},
+		{morm.EnglishPlural, "Box", "boxes"},
+		{morm.EnglishPlural, "Branch", "branches"},
+		{morm.SnakeCase, "BlogPost", "blog_post"},
+		{morm.SnakeCase, "HTTPServer", "http_server"},
+		{morm.SnakeCasePlural, "BlogCategory", "blog_categories"},
+		{explicit, "Author", "users"},
+		{explicit, "Category", "categories"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.strategy(tt.name); got != tt.want {
+			t.Errorf("Expected %q to map to %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestRegisterModel tests that model types are registered explicitly on a connection
+func TestRegisterModel(t *testing.T) {
+	db := connectLazy(t, "lazy-register-model")
+
+	if err := db.RegisterModel(&Company{}, "populate_companies"); err != nil {
+		t.Fatalf("Failed to register model: %v", err)
+	}
+	if err := db.RegisterModel(Customer{}, "populate_customers"); err != nil {
+		t.Fatalf("Failed to register model value: %v", err)
+	}
+	if err := db.RegisterModel("company", "companies"); err == nil {
+		t.Fatal("Expected a non-struct model to be rejected")
+	}
+	if err := db.RegisterModel(&Company{}, ""); err == nil {
+		t.Fatal("Expected an empty collection name to be rejected")
+	}
+
+	var missing *morm.DB
+	if err := missing.RegisterModel(&Company{}, "companies"); !errors.Is(err, morm.ErrNotConnected) {
+		t.Fatalf("Expected ErrNotConnected, got %v", err)
+	}
+}
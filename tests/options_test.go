@@ -0,0 +1,44 @@
+package morm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devsamahd/morm"
+)
+
+// TestConnectReturnsError tests that an unreachable server surfaces an error instead of exiting
+func TestConnectReturnsError(t *testing.T) {
+	_, err := morm.ConnectNamed("unreachable", "mongodb://localhost:1", "test_db",
+		morm.WithServerSelectionTimeout(100*time.Millisecond),
+		morm.WithStartupRetry(1, 10*time.Millisecond),
+	)
+	if err == nil {
+		t.Fatal("Expected an error when connecting to an unreachable server")
+	}
+
+	if morm.Use("unreachable") != nil {
+		t.Fatal("A failed connection should not be registered")
+	}
+}
+
+// TestConnectWithoutPing tests that disabling the ping returns a usable handle without a server round-trip
+func TestConnectWithoutPing(t *testing.T) {
+	db, err := morm.ConnectNamed("lazy", "mongodb://localhost:1", "test_db",
+		morm.WithPingOnConnect(false),
+		morm.WithAppName("morm-tests"),
+		morm.WithMaxPoolSize(5),
+	)
+	if err != nil {
+		t.Fatalf("Connect without ping returned an error: %v", err)
+	}
+
+	if _, err := db.Collection("test_collection", &TestModel{}); err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	if err := db.Disconnect(context.Background()); err != nil {
+		t.Fatalf("Disconnect returned an error: %v", err)
+	}
+}
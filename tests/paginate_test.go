@@ -0,0 +1,99 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestPaginate tests that pages are walked forwards and backwards with tokens
+func TestPaginate(t *testing.T) {
+	seedOrders(t, 5)
+
+	ctx := context.Background()
+	sort := bson.D{{Key: "number", Value: -1}}
+	numbers := func(res *morm.PageResult[*Order]) []int {
+		var n []int
+		for _, order := range res.Items {
+			n = append(n, order.Number)
+		}
+		return n
+	}
+
+	first, err := newOrders(t).Find().Sort(sort).Paginate(ctx, morm.Page{Size: 2, WithTotal: true})
+	if err != nil {
+		t.Fatalf("Paginate returned an error: %v", err)
+	}
+	if got := numbers(first); len(got) != 2 || got[0] != 4 || got[1] != 3 {
+		t.Fatalf("Expected orders [4 3] on the first page, got %v", got)
+	}
+	if !first.HasMore || first.PrevToken != "" || first.Total != 5 {
+		t.Fatalf("Unexpected first page metadata: %+v", first)
+	}
+
+	second, err := newOrders(t).Find().Sort(sort).Paginate(ctx, morm.Page{Size: 2, After: first.NextToken})
+	if err != nil {
+		t.Fatalf("Paginate returned an error: %v", err)
+	}
+	if got := numbers(second); len(got) != 2 || got[0] != 2 || got[1] != 1 {
+		t.Fatalf("Expected orders [2 1] on the second page, got %v", got)
+	}
+
+	last, err := newOrders(t).Find().Sort(sort).Paginate(ctx, morm.Page{Size: 2, After: second.NextToken})
+	if err != nil {
+		t.Fatalf("Paginate returned an error: %v", err)
+	}
+	if got := numbers(last); len(got) != 1 || got[0] != 0 || last.HasMore {
+		t.Fatalf("Expected only order 0 on the last page, got %v", got)
+	}
+
+	back, err := newOrders(t).Find().Sort(sort).Paginate(ctx, morm.Page{Size: 2, Before: last.PrevToken})
+	if err != nil {
+		t.Fatalf("Paginate returned an error: %v", err)
+	}
+	if got := numbers(back); len(got) != 2 || got[0] != 2 || got[1] != 1 || back.PrevToken == "" {
+		t.Fatalf("Expected orders [2 1] going back, got %v", got)
+	}
+
+	numbered, err := newOrders(t).Find().Sort(sort).Paginate(ctx, morm.Page{Size: 2, Number: 2})
+	if err != nil {
+		t.Fatalf("Paginate returned an error: %v", err)
+	}
+	if got := numbers(numbered); len(got) != 2 || got[0] != 2 || got[1] != 1 {
+		t.Fatalf("Expected orders [2 1] on page number 2, got %v", got)
+	}
+}
+
+// TestPaginateInvalidToken tests that a token issued for another sort order is rejected before querying
+func TestPaginateInvalidToken(t *testing.T) {
+	db := connectLazy(t, "lazy-paginate")
+
+	collect, err := db.Collection("test_collection", &TestModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	_, err = collect.Find().Paginate(context.Background(), morm.Page{Size: 10, After: "not-a-token"})
+	if !errors.Is(err, morm.ErrInvalidPageToken) {
+		t.Fatalf("Expected ErrInvalidPageToken, got %v", err)
+	}
+}
+
+// TestPaginateUnknownSortKey tests that sort keys which are not fields of the model are rejected before querying
+func TestPaginateUnknownSortKey(t *testing.T) {
+	db := connectLazy(t, "lazy-paginate-sort")
+
+	collect, err := db.Collection("test_collection", &TestModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	_, err = collect.Find().Sort(bson.D{{Key: "missing", Value: 1}}).Paginate(context.Background(), morm.Page{Size: 10})
+	if err == nil || !strings.Contains(err.Error(), `"missing"`) {
+		t.Fatalf("Expected the unknown sort key to be rejected, got %v", err)
+	}
+}
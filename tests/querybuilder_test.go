@@ -1,9 +1,12 @@
 package morm
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func TestExex(t *testing.T) {
@@ -32,3 +35,60 @@ func TestExex(t *testing.T) {
 
 	}
 }
+
+// TestExecContextCanceled tests that every operation honours its own context or the builder's context
+func TestExecContextCanceled(t *testing.T) {
+	db := connectLazy(t, "lazy-context")
+
+	collect, err := db.Collection("test_collection", &TestModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{name: "ExecContext", run: func() error {
+			_, err := collect.Find().ExecContext(ctx)
+			return err
+		}},
+		{name: "WithContext", run: func() error {
+			_, err := collect.WithContext(ctx).FindOne(nil).Exec()
+			return err
+		}},
+		{name: "Cursor", run: func() error {
+			_, err := collect.Find().Cursor(ctx)
+			return err
+		}},
+		{name: "Create", run: func() error {
+			_, err := collect.Create(&TestModel{}, ctx)
+			return err
+		}},
+		{name: "UpdateOne", run: func() error {
+			return collect.UpdateOne(bson.M{"field1": "a"}, bson.M{"$set": bson.M{"field2": 1}}, ctx)
+		}},
+		{name: "FindOneAndUpdate", run: func() error {
+			_, err := collect.FindOneAndUpdate(bson.M{"field1": "a"}, bson.M{"$set": bson.M{"field2": 1}}, ctx)
+			return err
+		}},
+		{name: "DeleteMany", run: func() error {
+			_, err := collect.DeleteMany(bson.M{"field1": "a"}, ctx)
+			return err
+		}},
+		{name: "Count", run: func() error {
+			_, err := collect.Find().Count(ctx)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, context.Canceled) {
+				t.Fatalf("Expected context.Canceled, got %v", err)
+			}
+		})
+	}
+}
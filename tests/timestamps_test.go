@@ -0,0 +1,45 @@
+package morm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type AuditedModel struct {
+	ID       interface{} `bson:"_id,omitempty"`
+	Created  time.Time   `bson:"created"`
+	Modified *time.Time  `bson:"modified"`
+}
+
+// TestCreateSetsTimestamps tests that Create stores the timestamps of the collection's policy against a running server
+func TestCreateSetsTimestamps(t *testing.T) {
+	if _, err := morm.Connect("mongodb://localhost:27017", "test_db"); err != nil {
+		t.Skipf("MongoDB is not available: %v", err)
+	}
+
+	clock := time.Date(2024, 1, 2, 3, 4, 5, 678901234, time.FixedZone("WAT", 3600))
+	expected := clock.UTC().Truncate(time.Millisecond)
+
+	audited, err := morm.Collection("audited", &AuditedModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+	audited.DeleteMany(bson.M{})
+	audited.Timestamps(morm.TimestampPolicy{CreatedAt: "created", UpdatedAt: "modified", Now: func() time.Time { return clock }})
+
+	if _, err := audited.Create(&AuditedModel{}); err != nil {
+		t.Fatalf("Create returned an error: %v", err)
+	}
+
+	found, err := audited.FindOne(bson.M{"created": expected}).Exec()
+	if err != nil || found == nil {
+		t.Fatalf("Expected the document to be stored with its timestamps, got %v", err)
+	}
+	stored := found.(*AuditedModel)
+	if !stored.Created.Equal(expected) || stored.Modified == nil || !stored.Modified.Equal(expected) {
+		t.Fatalf("Expected timestamps %v, got %+v", expected, stored)
+	}
+}
@@ -0,0 +1,174 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// requireReplicaSet skips the test unless the default connection is a replica set member,
+// since transactions are not supported by standalone servers.
+func requireReplicaSet(t *testing.T) {
+	t.Helper()
+
+	admin := morm.MongoDBInstance.Client.Database("admin")
+	var hello bson.M
+	if err := admin.RunCommand(context.Background(), bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		if err := admin.RunCommand(context.Background(), bson.D{{Key: "isMaster", Value: 1}}).Decode(&hello); err != nil {
+			t.Skipf("Failed to run hello: %v", err)
+		}
+	}
+	if _, ok := hello["setName"]; !ok {
+		t.Skip("MongoDB is not a replica set member")
+	}
+}
+
+// TestTransaction tests that operations run with the transaction context are committed or rolled back together
+func TestTransaction(t *testing.T) {
+	seedOrders(t, 1)
+	requireReplicaSet(t)
+	orders := newOrders(t)
+	ctx := context.Background()
+
+	err := morm.Transaction(ctx, func(tx context.Context) error {
+		if _, err := orders.Create(&Order{Number: 100}, tx); err != nil {
+			return err
+		}
+		return errRejected
+	})
+	if !errors.Is(err, errRejected) {
+		t.Fatalf("Expected the callback error, got %v", err)
+	}
+	if exists, _ := orders.DocumentExists(bson.M{"number": 100}); exists {
+		t.Fatal("Expected the aborted transaction to roll back the insert")
+	}
+
+	err = morm.Transaction(ctx, func(tx context.Context) error {
+		if _, err := orders.Create(&Order{Number: 101}, tx); err != nil {
+			return err
+		}
+		return orders.UpdateOne(bson.M{"number": 101}, morm.Set("number", 102), tx)
+	})
+	if err != nil {
+		t.Fatalf("Transaction returned an error: %v", err)
+	}
+	if exists, _ := orders.DocumentExists(bson.M{"number": 102}); !exists {
+		t.Fatal("Expected the committed transaction to persist the update")
+	}
+}
+
+// TestTransactionContext tests that the callback receives a context carrying the session and its error is returned
+func TestTransactionContext(t *testing.T) {
+	db := connectLazy(t, "lazy-transaction")
+
+	err := db.Transaction(context.Background(), func(tx context.Context) error {
+		if mongo.SessionFromContext(tx) == nil {
+			t.Error("Expected the transaction context to carry a session")
+		}
+		return errRejected
+	})
+	if !errors.Is(err, errRejected) {
+		t.Fatalf("Expected the callback error, got %v", err)
+	}
+
+	var missing *morm.DB
+	if err := missing.Transaction(context.Background(), func(tx context.Context) error { return nil }); !errors.Is(err, morm.ErrNotConnected) {
+		t.Fatalf("Expected ErrNotConnected, got %v", err)
+	}
+}
+
+// TestNestedTransaction tests that a failing nested transaction rolls back the enclosing one and runs the right callbacks
+func TestNestedTransaction(t *testing.T) {
+	db := connectLazy(t, "lazy-nested")
+
+	var events []string
+	err := db.Transaction(context.Background(), func(tx context.Context) error {
+		morm.AfterCommit(tx, func(ctx context.Context) { events = append(events, "commit") })
+		morm.AfterRollback(tx, func(ctx context.Context) { events = append(events, "rollback") })
+
+		nested := db.Transaction(tx, func(tx context.Context) error {
+			if mongo.SessionFromContext(tx) == nil {
+				t.Error("Expected the nested transaction to join the session")
+			}
+			return errRejected
+		})
+		if !errors.Is(nested, morm.ErrNestedRollback) || !errors.Is(nested, errRejected) {
+			t.Errorf("Expected ErrNestedRollback wrapping the nested error, got %v", nested)
+		}
+
+		// The enclosing callback ignores the nested failure
+		return nil
+	})
+
+	if !errors.Is(err, morm.ErrNestedRollback) {
+		t.Fatalf("Expected the transaction to be rolled back, got %v", err)
+	}
+	if len(events) != 1 || events[0] != "rollback" {
+		t.Fatalf("Expected only the rollback callback to run, got %v", events)
+	}
+}
+
+// TestNestedTransactionRetry tests that a transient error raised in a nested transaction retries the transaction
+func TestNestedTransactionRetry(t *testing.T) {
+	db := connectLazy(t, "lazy-nested-retry")
+
+	attempts := 0
+	err := db.Transaction(context.Background(), func(tx context.Context) error {
+		attempts++
+		nested := db.Transaction(tx, func(tx context.Context) error {
+			if attempts == 1 {
+				return mongo.CommandError{Code: 112, Name: "WriteConflict", Labels: []string{"TransientTransactionError"}}
+			}
+			return nil
+		})
+
+		var rollbackErr *morm.NestedRollbackError
+		if nested != nil && !errors.As(nested, &rollbackErr) {
+			t.Errorf("Expected a *NestedRollbackError, got %v", nested)
+		}
+
+		// The enclosing callback ignores the nested failure
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Expected the retried transaction to commit, got %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("Expected the transaction to be retried once, got %d attempts", attempts)
+	}
+}
+
+// TestAfterCommit tests that commit callbacks run after the outermost transaction commits, or immediately outside one
+func TestAfterCommit(t *testing.T) {
+	db := connectLazy(t, "lazy-commit")
+
+	var events []string
+	err := db.Transaction(context.Background(), func(tx context.Context) error {
+		return db.Transaction(tx, func(tx context.Context) error {
+			morm.AfterCommit(tx, func(ctx context.Context) { events = append(events, "commit") })
+			morm.AfterRollback(tx, func(ctx context.Context) { events = append(events, "rollback") })
+			if len(events) != 0 {
+				t.Error("Expected the commit callback to wait for the outermost transaction")
+			}
+			return nil
+		})
+	})
+
+	if err != nil {
+		t.Fatalf("Transaction returned an error: %v", err)
+	}
+	if len(events) != 1 || events[0] != "commit" {
+		t.Fatalf("Expected only the commit callback to run, got %v", events)
+	}
+
+	ran := false
+	morm.AfterCommit(context.Background(), func(ctx context.Context) { ran = true })
+	if !ran {
+		t.Fatal("Expected AfterCommit to run immediately outside a transaction")
+	}
+}
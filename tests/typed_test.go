@@ -0,0 +1,68 @@
+package morm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestTypedFind tests that the typed Find returns []*T
+func TestTypedFind(t *testing.T) {
+	mockDB, err := MockConnect("mongodb://localhost:27017", "test_db")
+	if err != nil {
+		t.Fatalf("Failed to mock MongoDB connection: %v", err)
+	}
+
+	tests, err := morm.For[TestModel]("test_collection")
+	if err != nil {
+		t.Fatalf("Failed to create typed collection: %v", err)
+	}
+
+	mockDB.collection.InsertOne(context.Background(), bson.M{"field1": "typed", "field2": 7})
+
+	result, err := tests.Find(bson.M{"field1": "typed"}).Limit(1).Exec()
+	if err != nil {
+		t.Fatalf("Typed Find returned an error: %v", err)
+	}
+
+	if len(result) != 1 || result[0].Field2 != 7 {
+		t.Fatalf("Expected one document with Field2 7, got %+v", result)
+	}
+
+	mockDB.collection.DeleteMany(context.Background(), bson.M{"field1": "typed"})
+}
+
+// TestTypedFindOne tests that the typed FindOne returns *T
+func TestTypedFindOne(t *testing.T) {
+	mockDB, err := MockConnect("mongodb://localhost:27017", "test_db")
+	if err != nil {
+		t.Fatalf("Failed to mock MongoDB connection: %v", err)
+	}
+
+	tests, err := morm.For[TestModel]("test_collection")
+	if err != nil {
+		t.Fatalf("Failed to create typed collection: %v", err)
+	}
+
+	mockDB.collection.InsertOne(context.Background(), bson.M{"field1": "typed-one", "field2": 8})
+
+	result, err := tests.FindOne(bson.M{"field1": "typed-one"}).Exec()
+	if err != nil {
+		t.Fatalf("Typed FindOne returned an error: %v", err)
+	}
+
+	if result.Field2 != 8 {
+		t.Fatalf("Expected Field2 8, got %d", result.Field2)
+	}
+
+	removed, err := tests.FindOneAndRemove(bson.M{"field1": "typed-one"})
+	if err != nil {
+		t.Fatalf("Typed FindOneAndRemove returned an error: %v", err)
+	}
+
+	if removed == nil || removed.Field1 != "typed-one" {
+		t.Fatalf("Expected removed document, got %+v", removed)
+	}
+}
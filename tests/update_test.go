@@ -1 +1,81 @@
 package morm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestUpdateOperators tests that operator updates are applied as is, with updatedAt merged into $set
+func TestUpdateOperators(t *testing.T) {
+	mockDB, err := MockConnect("mongodb://localhost:27017", "test_db")
+	if err != nil {
+		t.Fatalf("Failed to mock MongoDB connection: %v", err)
+	}
+
+	qb, err := morm.Collection("test_collection", &TestModel{})
+	if err != nil {
+		t.Fatalf("Failed to mock collection: %v", err)
+	}
+
+	mockDB.collection.InsertOne(context.Background(), bson.M{"field1": "operators", "field2": 1})
+
+	err = qb.Update(bson.M{"field1": "operators"}, bson.M{"$inc": bson.M{"field2": 2}})
+	if err != nil {
+		t.Fatalf("Update returned an error: %v", err)
+	}
+
+	err = qb.UpdateOne(bson.M{"field1": "operators"}, bson.D{{Key: "$inc", Value: bson.D{{Key: "field2", Value: 3}}}})
+	if err != nil {
+		t.Fatalf("UpdateOne returned an error: %v", err)
+	}
+
+	result, err := qb.FindOneAndUpdate(bson.M{"field1": "operators"}, bson.M{"$set": bson.M{"field1": "updated"}})
+	if err != nil {
+		t.Fatalf("FindOneAndUpdate returned an error: %v", err)
+	}
+
+	updated := result.(*TestModel)
+	if updated.Field1 != "updated" || updated.Field2 != 6 || updated.UpdatedAt.IsZero() {
+		t.Fatalf("Expected the operators to be applied with updatedAt set, got %+v", updated)
+	}
+
+	mockDB.collection.DeleteMany(context.Background(), bson.M{"field1": "updated"})
+}
+
+// TestUpdateStruct tests that a plain struct update sets its fields without overwriting _id or createdAt
+func TestUpdateStruct(t *testing.T) {
+	mockDB, err := MockConnect("mongodb://localhost:27017", "test_db")
+	if err != nil {
+		t.Fatalf("Failed to mock MongoDB connection: %v", err)
+	}
+
+	qb, err := morm.Collection("test_collection", &TestModel{})
+	if err != nil {
+		t.Fatalf("Failed to mock collection: %v", err)
+	}
+
+	model := &TestModel{Field1: "struct", Field2: 1}
+	if _, err := qb.Create(model); err != nil {
+		t.Fatalf("Create returned an error: %v", err)
+	}
+
+	err = qb.UpdateOne(bson.M{"_id": model.ID}, TestModel{Field1: "struct", Field2: 2})
+	if err != nil {
+		t.Fatalf("UpdateOne returned an error: %v", err)
+	}
+
+	result, err := qb.FindOne(bson.M{"_id": model.ID}).Exec()
+	if err != nil {
+		t.Fatalf("FindOne returned an error: %v", err)
+	}
+
+	found := result.(*TestModel)
+	if found.Field2 != 2 || !found.CreatedAt.Equal(model.CreatedAt) {
+		t.Fatalf("Expected Field2 2 and the original createdAt, got %+v", found)
+	}
+
+	mockDB.collection.DeleteMany(context.Background(), bson.M{"field1": "struct"})
+}
@@ -0,0 +1,56 @@
+package morm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestUpdateBuilderDocument tests that the builder groups fields by operator in call order
+func TestUpdateBuilderDocument(t *testing.T) {
+	update := morm.Set("field1", "a").Inc("field2", 1).Set("field1", "b").Push("tags", "go").Unset("old", "older").CurrentDate("seenAt")
+
+	expected := bson.D{
+		{Key: "$set", Value: bson.D{{Key: "field1", Value: "b"}}},
+		{Key: "$inc", Value: bson.D{{Key: "field2", Value: 1}}},
+		{Key: "$push", Value: bson.D{{Key: "tags", Value: "go"}}},
+		{Key: "$unset", Value: bson.D{{Key: "old", Value: ""}, {Key: "older", Value: ""}}},
+		{Key: "$currentDate", Value: bson.D{{Key: "seenAt", Value: true}}},
+	}
+	if !reflect.DeepEqual(update.Document(), expected) {
+		t.Fatalf("Expected %v, got %v", expected, update.Document())
+	}
+}
+
+// TestUpdateBuilderUnknownField tests that fields are checked against the model before the update is sent
+func TestUpdateBuilderUnknownField(t *testing.T) {
+	db := connectLazy(t, "lazy-update-builder")
+
+	collect, err := db.Collection("test_collection", &TestModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	err = collect.UpdateOne(bson.M{"field1": "a"}, morm.Set("field1", "b").Inc("missing", 1))
+	if !errors.Is(err, morm.ErrUnknownField) {
+		t.Fatalf("Expected ErrUnknownField, got %v", err)
+	}
+
+	_, err = collect.FindOneAndUpdate(bson.M{"field1": "a"}, morm.Set("testmodel2.nope", 1))
+	if !errors.Is(err, morm.ErrUnknownField) {
+		t.Fatalf("Expected ErrUnknownField for a nested path, got %v", err)
+	}
+
+	validated, err := db.Collection("validated", &ValidatedModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	err = validated.Update(bson.M{}, morm.Set("role", "owner"))
+	if paths := failingPaths(t, err); paths["role"] != "enum" {
+		t.Fatalf("Expected the builder's $set to be validated, got %v", paths)
+	}
+}
@@ -1 +1,198 @@
 package morm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type ValidatedAddress struct {
+	City string `bson:"city" morm:"required"`
+	Zip  string `bson:"zip" morm:"match=^[0-9]{5}$"`
+}
+
+type ValidatedLine struct {
+	SKU      string `bson:"sku" morm:"required"`
+	Quantity int    `bson:"quantity" morm:"min=1,max=100"`
+}
+
+type ValidatedModel struct {
+	morm.Model `bson:",inline"`
+	Name       string            `bson:"name" morm:"required,min=3,max=8"`
+	Role       string            `bson:"role" morm:"enum=admin|member"`
+	Address    *ValidatedAddress `bson:"address"`
+	Lines      []ValidatedLine   `bson:"lines" morm:"max=2"`
+	Nickname   *string           `bson:"nickname" morm:"min=2"`
+}
+
+func failingPaths(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	var validationErr *morm.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a *ValidationError, got %v", err)
+	}
+
+	paths := make(map[string]string)
+	for _, fieldErr := range validationErr.Errors {
+		paths[fieldErr.Path] = fieldErr.Rule
+	}
+	return paths
+}
+
+// TestValidate tests that every failing field is reported with its path
+func TestValidate(t *testing.T) {
+	model := &ValidatedModel{
+		Name:    "Al",
+		Role:    "owner",
+		Address: &ValidatedAddress{Zip: "12a45"},
+		Lines:   []ValidatedLine{{SKU: "a", Quantity: 1}, {Quantity: 0}},
+	}
+
+	paths := failingPaths(t, morm.Validate(model))
+
+	expected := map[string]string{
+		"name":             "min",
+		"role":             "enum",
+		"address.city":     "required",
+		"address.zip":      "match",
+		"lines.1.sku":      "required",
+		"lines.1.quantity": "min",
+	}
+	for path, rule := range expected {
+		if paths[path] != rule {
+			t.Errorf("Expected %s to fail %s, got %q", path, rule, paths[path])
+		}
+	}
+	if len(paths) != len(expected) {
+		t.Errorf("Expected %d failing fields, got %v", len(expected), paths)
+	}
+}
+
+// TestValidateValid tests that a valid model passes and that nil values skip non-required rules
+func TestValidateValid(t *testing.T) {
+	model := &ValidatedModel{Name: "Alice", Role: "admin"}
+	if err := morm.Validate(model); err != nil {
+		t.Fatalf("Expected a valid model, got %v", err)
+	}
+}
+
+type OptionalModel struct {
+	Role     string `bson:"role" morm:"enum=admin|member"`
+	Zip      string `bson:"zip" morm:"match=^[0-9]{5}$"`
+	Nickname string `bson:"nickname" morm:"min=2"`
+	Quantity int    `bson:"quantity" morm:"min=1"`
+}
+
+// TestValidateOptional tests that enum and match are skipped for empty strings while zero numbers are checked
+func TestValidateOptional(t *testing.T) {
+	if err := morm.Validate(&OptionalModel{Nickname: "al", Quantity: 1}); err != nil {
+		t.Fatalf("Expected empty enum and match fields to pass, got %v", err)
+	}
+
+	paths := failingPaths(t, morm.Validate(&OptionalModel{}))
+	if len(paths) != 2 || paths["nickname"] != "min" || paths["quantity"] != "min" {
+		t.Fatalf("Expected the empty nickname and zero quantity to fail min, got %v", paths)
+	}
+
+	paths = failingPaths(t, morm.Validate(&OptionalModel{Role: "owner", Zip: "1", Nickname: "a", Quantity: 1}))
+	if paths["role"] != "enum" || paths["zip"] != "match" || paths["nickname"] != "min" {
+		t.Fatalf("Expected set fields to be validated, got %v", paths)
+	}
+}
+
+// TestCreateValidates tests that Create rejects invalid models before reaching the server
+func TestCreateValidates(t *testing.T) {
+	db := connectLazy(t, "lazy-validator")
+
+	collect, err := db.Collection("validated", &ValidatedModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	_, err = collect.Create(&ValidatedModel{Role: "admin"})
+	if paths := failingPaths(t, err); paths["name"] != "required" {
+		t.Fatalf("Expected name to be required, got %v", paths)
+	}
+
+	err = collect.UpdateOne(bson.M{"name": "Alice"}, bson.M{"$set": bson.M{"role": "owner", "address.zip": "x"}})
+	if paths := failingPaths(t, err); paths["role"] != "enum" || paths["address.zip"] != "match" {
+		t.Fatalf("Expected role and address.zip to fail, got %v", paths)
+	}
+
+	optional, err := db.Collection("optional", &OptionalModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	err = optional.UpdateOne(bson.M{}, bson.M{"$set": bson.M{"quantity": 0}})
+	if paths := failingPaths(t, err); paths["quantity"] != "min" {
+		t.Fatalf("Expected a zero quantity to fail min, got %v", paths)
+	}
+}
+
+type ScheduledModel struct {
+	morm.Model `bson:",inline"`
+	StartDate  time.Time `bson:"startDate" morm:"required"`
+	EndDate    time.Time `bson:"endDate" morm:"after=startDate"`
+	Code       string    `bson:"code" morm:"uppercase"`
+}
+
+// Validate implements morm.Validator
+func (m *ScheduledModel) Validate(ctx context.Context) error {
+	if m.Code == "RESERVED" {
+		return &morm.FieldError{Path: "code", Message: "is reserved"}
+	}
+	return nil
+}
+
+func init() {
+	morm.RegisterValidator("after", func(ctx context.Context, f *morm.FieldValue) error {
+		end := f.Value.(time.Time)
+		for i := 0; i < f.Parent.NumField(); i++ {
+			if f.Parent.Type().Field(i).Tag.Get("bson") == f.Param {
+				if !end.After(f.Parent.Field(i).Interface().(time.Time)) {
+					return errors.New("must be after " + f.Param)
+				}
+			}
+		}
+		return nil
+	})
+	morm.RegisterValidator("uppercase", func(ctx context.Context, f *morm.FieldValue) error {
+		if f.Query == nil || morm.CollectionFromContext(ctx) == nil {
+			return nil
+		}
+		if code := f.Value.(string); code != strings.ToUpper(code) {
+			return errors.New("must be uppercase")
+		}
+		return nil
+	})
+}
+
+// TestCustomValidators tests registered tag validators and the Validator interface
+func TestCustomValidators(t *testing.T) {
+	start := time.Now()
+	model := &ScheduledModel{StartDate: start, EndDate: start.Add(-time.Hour), Code: "RESERVED"}
+
+	paths := failingPaths(t, morm.Validate(model))
+	if paths["endDate"] != "after" || paths["code"] != "validate" {
+		t.Fatalf("Expected endDate and code to fail, got %v", paths)
+	}
+
+	db := connectLazy(t, "lazy-custom-validator")
+
+	collect, err := db.Collection("scheduled", &ScheduledModel{})
+	if err != nil {
+		t.Fatalf("Failed to create Collection: %v", err)
+	}
+
+	_, err = collect.Create(&ScheduledModel{StartDate: start, EndDate: start.Add(time.Hour), Code: "lower"})
+	if paths := failingPaths(t, err); len(paths) != 1 || paths["code"] != "uppercase" {
+		t.Fatalf("Expected only code to fail uppercase, got %v", paths)
+	}
+}
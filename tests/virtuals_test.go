@@ -1 +1,280 @@
 package morm
+
+import (
+	"testing"
+
+	"github.com/devsamahd/morm"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Company struct {
+	morm.Model    `bson:",inline"`
+	Name          string `bson:"name"`
+	CustomerCount int    `bson:"customerCount" ref:"populate_customers" localField:"_id" foreignField:"companyId" count:"true"`
+}
+
+type Customer struct {
+	morm.Model `bson:",inline"`
+	Name       string             `bson:"name"`
+	CompanyID  primitive.ObjectID `bson:"companyId"`
+	Company    *Company           `bson:"company" ref:"populate_companies" localField:"companyId" foreignField:"_id" justOne:"true"`
+}
+
+type Order struct {
+	morm.Model `bson:",inline"`
+	Number     int                `bson:"number"`
+	CustomerID primitive.ObjectID `bson:"customerId"`
+	Customer   *Customer          `bson:"customer" ref:"populate_customers" localField:"customerId" foreignField:"_id" justOne:"true"`
+}
+
+type Activity struct {
+	morm.Model `bson:",inline"`
+	TargetID   primitive.ObjectID `bson:"targetId"`
+	TargetType string             `bson:"targetType"`
+	Target     bson.Raw           `bson:"target" refPath:"targetType" localField:"targetId" foreignField:"_id" justOne:"true"`
+}
+
+// seedOrders inserts n orders, each referencing its own customer of a shared company
+func seedOrders(tb testing.TB, n int) {
+	tb.Helper()
+
+	if _, err := morm.Connect("mongodb://localhost:27017", "test_db"); err != nil {
+		tb.Skipf("MongoDB is not available: %v", err)
+	}
+
+	companies, err := morm.Collection("populate_companies", &Company{})
+	if err != nil {
+		tb.Fatalf("Failed to create companies collection: %v", err)
+	}
+	customers, err := morm.Collection("populate_customers", &Customer{})
+	if err != nil {
+		tb.Fatalf("Failed to create customers collection: %v", err)
+	}
+	orders, err := morm.Collection("populate_orders", &Order{})
+	if err != nil {
+		tb.Fatalf("Failed to create orders collection: %v", err)
+	}
+
+	companies.DeleteMany(bson.M{})
+	customers.DeleteMany(bson.M{})
+	orders.DeleteMany(bson.M{})
+
+	companyID, err := companies.Create(&Company{Name: "company"})
+	if err != nil {
+		tb.Fatalf("Failed to create company: %v", err)
+	}
+
+	for i := 0; i < n; i++ {
+		id, err := customers.Create(&Customer{Name: "customer", CompanyID: companyID})
+		if err != nil {
+			tb.Fatalf("Failed to create customer: %v", err)
+		}
+		if _, err := orders.Create(&Order{Number: i, CustomerID: id}); err != nil {
+			tb.Fatalf("Failed to create order: %v", err)
+		}
+	}
+}
+
+// newOrders returns the typed orders collection
+func newOrders(tb testing.TB) *morm.TypedCollection[Order] {
+	tb.Helper()
+
+	orders, err := morm.For[Order]("populate_orders")
+	if err != nil {
+		tb.Fatalf("Failed to create orders collection: %v", err)
+	}
+	return orders
+}
+
+// TestFindPopulate tests that Find populates every document with a single aggregation
+func TestFindPopulate(t *testing.T) {
+	seedOrders(t, 5)
+
+	orders := newOrders(t)
+	result, err := orders.Find().Sort(bson.D{{Key: "number", Value: 1}}).Skip(1).Limit(3).Populate([]string{"Customer"}).Exec()
+	if err != nil {
+		t.Fatalf("Find with Populate returned an error: %v", err)
+	}
+
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 orders, got %d", len(result))
+	}
+	for i, order := range result {
+		if order.Number != i+1 {
+			t.Errorf("Expected order %d, got %d", i+1, order.Number)
+		}
+		if order.Customer == nil || order.Customer.ID != order.CustomerID {
+			t.Errorf("Expected order %d to be populated with its customer, got %+v", order.Number, order.Customer)
+		}
+	}
+}
+
+// TestFindPopulateNested tests that paths populate references of populated documents and trim them
+func TestFindPopulateNested(t *testing.T) {
+	seedOrders(t, 2)
+
+	orders := newOrders(t)
+	result, err := orders.Find().Populate(morm.Path("customer").Select("name").Populate(morm.Path("Company"))).Exec()
+	if err != nil {
+		t.Fatalf("Find with nested Populate returned an error: %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 orders, got %d", len(result))
+	}
+	for _, order := range result {
+		customer := order.Customer
+		if customer == nil || customer.Name != "customer" {
+			t.Fatalf("Expected order %d to be populated with its customer, got %+v", order.Number, customer)
+		}
+		if customer.Company == nil || customer.Company.Name != "company" {
+			t.Errorf("Expected the customer's company to be populated, got %+v", customer.Company)
+		}
+		if !customer.CompanyID.IsZero() {
+			t.Errorf("Expected companyId to be trimmed by Select, got %v", customer.CompanyID)
+		}
+	}
+}
+
+// TestPopulateCount tests that count fields are populated with the number of related documents
+func TestPopulateCount(t *testing.T) {
+	seedOrders(t, 3)
+
+	companies, err := morm.For[Company]("populate_companies")
+	if err != nil {
+		t.Fatalf("Failed to create companies collection: %v", err)
+	}
+
+	company, err := companies.FindOne(bson.M{"name": "company"}).Populate("CustomerCount").Exec()
+	if err != nil {
+		t.Fatalf("FindOne with a count Populate returned an error: %v", err)
+	}
+
+	if company.CustomerCount != 3 {
+		t.Fatalf("Expected 3 customers, got %d", company.CustomerCount)
+	}
+}
+
+// TestPopulateRefPath tests that refPath fields are populated from the collection named by each document
+func TestPopulateRefPath(t *testing.T) {
+	seedOrders(t, 1)
+
+	db := morm.Use(morm.DefaultConnection)
+	db.SetNamingStrategy(morm.Explicit(map[string]string{
+		"customer": "populate_customers",
+		"company":  "populate_companies",
+	}, morm.LowerPlural))
+	defer db.SetNamingStrategy(nil)
+
+	customer, err := morm.For[Customer]("populate_customers")
+	if err != nil {
+		t.Fatalf("Failed to create customers collection: %v", err)
+	}
+	target, err := customer.FindOne(bson.M{"name": "customer"}).Exec()
+	if err != nil {
+		t.Fatalf("Failed to find customer: %v", err)
+	}
+
+	activities, err := morm.For[Activity]("populate_activities")
+	if err != nil {
+		t.Fatalf("Failed to create activities collection: %v", err)
+	}
+	activities.DeleteMany(bson.M{})
+	activities.Create(&Activity{TargetID: target.ID, TargetType: "customer"})
+	activities.Create(&Activity{TargetID: target.CompanyID, TargetType: "company"})
+
+	result, err := activities.Find().Sort(bson.D{{Key: "createdAt", Value: 1}}).Populate("Target").Exec()
+	if err != nil {
+		t.Fatalf("Find with a refPath Populate returned an error: %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 activities, got %d", len(result))
+	}
+	for _, activity := range result {
+		if name, _ := activity.Target.Lookup("name").StringValueOK(); name != activity.TargetType {
+			t.Errorf("Expected the %s target to be populated, got %v", activity.TargetType, activity.Target)
+		}
+	}
+}
+
+type Feed struct {
+	morm.Model `bson:",inline"`
+	TargetIDs  []primitive.ObjectID `bson:"targetIds"`
+	TargetType string               `bson:"targetType"`
+	Targets    []bson.Raw           `bson:"targets" refPath:"targetType" localField:"targetIds" foreignField:"_id"`
+}
+
+// TestPopulateRefPathOptions tests that the sort and limit of a refPath path apply across all references of a document
+func TestPopulateRefPathOptions(t *testing.T) {
+	seedOrders(t, 3)
+
+	db := morm.Use(morm.DefaultConnection)
+	db.SetNamingStrategy(morm.Explicit(map[string]string{"order": "populate_orders"}, morm.LowerPlural))
+	defer db.SetNamingStrategy(nil)
+
+	orders, err := newOrders(t).Find().Sort(bson.D{{Key: "number", Value: 1}}).Exec()
+	if err != nil || len(orders) != 3 {
+		t.Fatalf("Failed to find orders: %v", err)
+	}
+
+	feeds, err := morm.For[Feed]("populate_feeds")
+	if err != nil {
+		t.Fatalf("Failed to create feeds collection: %v", err)
+	}
+	feeds.DeleteMany(bson.M{})
+	feeds.Create(&Feed{TargetIDs: []primitive.ObjectID{orders[0].ID, orders[1].ID, orders[2].ID}, TargetType: "order"})
+
+	path := morm.Path("Targets").Sort(bson.D{{Key: "number", Value: -1}}).Limit(2)
+	result, err := feeds.Find().Populate(path).Exec()
+	if err != nil || len(result) != 1 {
+		t.Fatalf("Find with a refPath Populate returned an error: %v", err)
+	}
+
+	var numbers []int32
+	for _, target := range result[0].Targets {
+		numbers = append(numbers, target.Lookup("number").Int32())
+	}
+	if len(numbers) != 2 || numbers[0] != 2 || numbers[1] != 1 {
+		t.Fatalf("Expected orders [2 1], got %v", numbers)
+	}
+
+	_, err = feeds.Find().Populate(morm.Path("Targets").Populate("Customer")).Exec()
+	if err == nil {
+		t.Fatal("Expected a nested populate under a refPath path to be rejected")
+	}
+}
+
+// BenchmarkFindPopulate measures Find with Populate, which runs one aggregation for all documents
+func BenchmarkFindPopulate(b *testing.B) {
+	seedOrders(b, 500)
+	orders := newOrders(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := orders.Find().Populate([]string{"Customer"}).Exec(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFindPopulatePerDocument measures the previous behaviour of one populate aggregation per document
+func BenchmarkFindPopulatePerDocument(b *testing.B) {
+	seedOrders(b, 500)
+	orders := newOrders(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		result, err := orders.Find().Projection(bson.D{{Key: "_id", Value: 1}}).Exec()
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, order := range result {
+			_, err := orders.FindOne(bson.M{"_id": order.ID}).Populate([]string{"Customer"}).Exec()
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package morm
+
+import (
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TimestampPolicy configures the timestamps morm maintains for a collection.
+// The zero value uses the "createdAt" and "updatedAt" fields of Model and the system clock.
+type TimestampPolicy struct {
+	// CreatedAt is the bson field set when a document is created. Defaults to "createdAt".
+	CreatedAt string
+	// UpdatedAt is the bson field set when a document is created or updated. Defaults to "updatedAt".
+	UpdatedAt string
+	// Disabled turns off automatic timestamps for the collection.
+	Disabled bool
+	// Now returns the current time. Defaults to time.Now; tests can provide a fixed clock.
+	Now func() time.Time
+}
+
+// Timestamps sets the timestamp policy of the collection.
+// The policy is kept on the collection, so it applies to every builder created for the same collection and model.
+//
+// Example:
+//
+//	qb.Timestamps(morm.TimestampPolicy{CreatedAt: "created", UpdatedAt: "modified"})
+//
+// This method is useful for models with custom timestamp fields or for freezing the clock in tests.
+func (qb *CollectQueryBuilder) Timestamps(policy TimestampPolicy) *CollectQueryBuilder {
+	qb.c.setTimestamps(policy)
+	return qb
+}
+
+// setTimestamps stores the timestamp policy of the collection.
+func (c *Collect) setTimestamps(policy TimestampPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.timestamps = &policy
+}
+
+// timestampPolicy returns the collection's timestamp policy with defaults applied.
+func (c *Collect) timestampPolicy() TimestampPolicy {
+	c.mu.RLock()
+	var policy TimestampPolicy
+	if c.timestamps != nil {
+		policy = *c.timestamps
+	}
+	c.mu.RUnlock()
+
+	if policy.CreatedAt == "" {
+		policy.CreatedAt = "createdAt"
+	}
+	if policy.UpdatedAt == "" {
+		policy.UpdatedAt = "updatedAt"
+	}
+	if policy.Now == nil {
+		policy.Now = time.Now
+	}
+	return policy
+}
+
+// now returns the current time of the policy in UTC, truncated to milliseconds to match BSON dates.
+func (p TimestampPolicy) now() time.Time {
+	return p.Now().UTC().Truncate(time.Millisecond)
+}
+
+// prepareCreate assigns a new ObjectID, the defaults of fields tagged "default=" and the created-at and
+// updated-at timestamps to a model pointer before it is inserted. Fields that are already set are kept.
+func (c *Collect) prepareCreate(model interface{}) error {
+	value := reflect.ValueOf(model)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	value = value.Elem()
+
+	if id, ok := fieldValueByBSONName(value, "_id"); ok && id.CanSet() && id.Type() == reflect.TypeOf(primitive.ObjectID{}) {
+		if id.Interface().(primitive.ObjectID).IsZero() {
+			id.Set(reflect.ValueOf(primitive.NewObjectID()))
+		}
+	}
+
+	if err := applyDefaults(value, ""); err != nil {
+		return err
+	}
+
+	policy := c.timestampPolicy()
+	if policy.Disabled {
+		return nil
+	}
+
+	now := policy.now()
+	for _, name := range []string{policy.CreatedAt, policy.UpdatedAt} {
+		field, ok := fieldValueByBSONName(value, name)
+		if ok && field.CanSet() {
+			setTime(field, now)
+		}
+	}
+	return nil
+}
+
+// setTime sets a time.Time or *time.Time field to now if it is unset.
+func setTime(field reflect.Value, now time.Time) {
+	switch {
+	case field.Type() == timeType:
+		if field.Interface().(time.Time).IsZero() {
+			field.Set(reflect.ValueOf(now))
+		}
+	case field.Kind() == reflect.Ptr && field.Type().Elem() == timeType:
+		if field.IsNil() {
+			field.Set(reflect.ValueOf(&now))
+		}
+	}
+}
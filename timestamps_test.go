@@ -0,0 +1,97 @@
+package morm
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type audited struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	Created  time.Time          `bson:"created"`
+	Modified *time.Time         `bson:"modified"`
+}
+
+// TestPrepareCreate tests the ID and timestamps set on a model before it is inserted
+func TestPrepareCreate(t *testing.T) {
+	clock := time.Date(2024, 1, 2, 3, 4, 5, 678901234, time.FixedZone("WAT", 3600))
+	now := clock.UTC().Truncate(time.Millisecond)
+	set := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		policy   TimestampPolicy
+		model    *audited
+		created  time.Time
+		modified *time.Time
+	}{
+		{
+			name:     "custom fields",
+			policy:   TimestampPolicy{CreatedAt: "created", UpdatedAt: "modified"},
+			model:    &audited{},
+			created:  now,
+			modified: &now,
+		},
+		{
+			name:     "set fields are kept",
+			policy:   TimestampPolicy{CreatedAt: "created", UpdatedAt: "modified"},
+			model:    &audited{Created: set, Modified: &set},
+			created:  set,
+			modified: &set,
+		},
+		{
+			name:     "default fields do not match",
+			policy:   TimestampPolicy{},
+			model:    &audited{},
+			created:  time.Time{},
+			modified: nil,
+		},
+		{
+			name:     "disabled",
+			policy:   TimestampPolicy{CreatedAt: "created", UpdatedAt: "modified", Disabled: true},
+			model:    &audited{},
+			created:  time.Time{},
+			modified: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.policy.Now = func() time.Time { return clock }
+			c := &Collect{timestamps: &tt.policy}
+
+			if err := c.prepareCreate(tt.model); err != nil {
+				t.Fatalf("Failed to prepare model: %v", err)
+			}
+			if tt.model.ID.IsZero() {
+				t.Fatal("Expected an ID to be assigned")
+			}
+			if !tt.model.Created.Equal(tt.created) {
+				t.Fatalf("Expected created %v, got %v", tt.created, tt.model.Created)
+			}
+			if (tt.modified == nil) != (tt.model.Modified == nil) || (tt.modified != nil && !tt.model.Modified.Equal(*tt.modified)) {
+				t.Fatalf("Expected modified %v, got %v", tt.modified, tt.model.Modified)
+			}
+		})
+	}
+}
+
+// TestPrepareCreateModel tests that the timestamps of Model are set in UTC with millisecond precision and that an ID is kept
+func TestPrepareCreateModel(t *testing.T) {
+	clock := time.Date(2024, 1, 2, 3, 4, 5, 678901234, time.FixedZone("WAT", 3600))
+	c := &Collect{timestamps: &TimestampPolicy{Now: func() time.Time { return clock }}}
+
+	id := primitive.NewObjectID()
+	model := &replaced{Model: Model{ID: id}}
+	if err := c.prepareCreate(model); err != nil {
+		t.Fatalf("Failed to prepare model: %v", err)
+	}
+
+	expected := time.Date(2024, 1, 2, 2, 4, 5, 678000000, time.UTC)
+	if model.ID != id {
+		t.Fatalf("Expected ID %v to be kept, got %v", id, model.ID)
+	}
+	if model.CreatedAt != expected || model.UpdatedAt != expected {
+		t.Fatalf("Expected timestamps %v, got %v and %v", expected, model.CreatedAt, model.UpdatedAt)
+	}
+}
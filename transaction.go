@@ -0,0 +1,174 @@
+package morm
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrNestedRollback is matched by the *NestedRollbackError returned when a nested Transaction fails,
+// marking the enclosing transaction rollback-only.
+var ErrNestedRollback = errors.New("nested transaction rolled back")
+
+// NestedRollbackError is returned by a nested Transaction whose callback failed, and by the outermost Transaction
+// when its callback succeeds after a nested transaction failed. It matches ErrNestedRollback with errors.Is
+// and unwraps to the error of the nested callback, so transient transaction errors raised in a nested
+// transaction keep their labels and the transaction is retried.
+type NestedRollbackError struct {
+	Err error
+}
+
+// Error returns the error message including the error of the nested callback.
+func (e *NestedRollbackError) Error() string {
+	return ErrNestedRollback.Error() + ": " + e.Err.Error()
+}
+
+// Is reports whether target is ErrNestedRollback.
+func (e *NestedRollbackError) Is(target error) bool {
+	return target == ErrNestedRollback
+}
+
+// Unwrap returns the error of the nested callback.
+func (e *NestedRollbackError) Unwrap() error {
+	return e.Err
+}
+
+// txKey is the context key of the transaction state.
+type txKey struct{}
+
+// txState is the state of a transaction shared by nested Transaction calls through the context.
+// It is reset every time the transaction is retried.
+type txState struct {
+	db            *DB
+	mu            sync.Mutex
+	rollbackErr   error
+	afterCommit   []func(ctx context.Context)
+	afterRollback []func(ctx context.Context)
+}
+
+// Transaction runs fn in a transaction on the connection and commits it if fn returns nil.
+// The transaction is aborted if fn returns an error, which is then returned to the caller.
+//
+// The context passed to fn carries the transaction's session. Every operation run with it, including
+// Create, Update, Delete, FindOneAndUpdate, queries and populate aggregations, joins the transaction.
+// Operations run with another context do not.
+//
+// fn is retried when the transaction fails with a TransientTransactionError, and the commit is retried on an
+// UnknownTransactionCommitResult error, so fn must be safe to run more than once.
+//
+// A Transaction called with a context of a running transaction joins it instead of starting a new one.
+// If the nested fn returns an error, the nested Transaction returns it wrapped in a *NestedRollbackError and the
+// whole transaction is rolled back, even if the enclosing fn ignores the error.
+// Side effects that must wait for the outcome are registered with AfterCommit and AfterRollback.
+// Transactions require a replica set or a sharded cluster.
+//
+// Example:
+//
+//	err := db.Transaction(ctx, func(tx context.Context) error {
+//	  if err := accounts.UpdateOne(bson.M{"_id": from}, morm.Inc("balance", -amount), tx); err != nil {
+//	    return err
+//	  }
+//	  return accounts.UpdateOne(bson.M{"_id": to}, morm.Inc("balance", amount), tx)
+//	})
+func (db *DB) Transaction(ctx context.Context, fn func(tx context.Context) error, opts ...*options.TransactionOptions) error {
+	if db == nil || db.Client == nil {
+		return ErrNotConnected
+	}
+
+	// A nested transaction joins the enclosing one
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		if state.db != db {
+			return errors.New("nested transaction must run on the connection of the enclosing transaction")
+		}
+		if err := fn(ctx); err != nil {
+			state.mu.Lock()
+			if state.rollbackErr == nil {
+				state.rollbackErr = err
+			}
+			state.mu.Unlock()
+			return &NestedRollbackError{Err: err}
+		}
+		return nil
+	}
+
+	session, err := db.Client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	var state *txState
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		state = &txState{db: db}
+		if err := fn(context.WithValue(sessionContext, txKey{}, state)); err != nil {
+			return nil, err
+		}
+
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		if state.rollbackErr != nil {
+			return nil, &NestedRollbackError{Err: state.rollbackErr}
+		}
+		return nil, nil
+	}, opts...)
+
+	if state != nil {
+		callbacks := state.afterCommit
+		if err != nil {
+			callbacks = state.afterRollback
+		}
+		for _, callback := range callbacks {
+			callback(ctx)
+		}
+	}
+
+	return err
+}
+
+// Transaction runs fn in a transaction on the default connection. See DB.Transaction.
+func Transaction(ctx context.Context, fn func(tx context.Context) error, opts ...*options.TransactionOptions) error {
+	return Use(DefaultConnection).Transaction(ctx, fn, opts...)
+}
+
+// AfterCommit registers a callback that runs after the transaction carried by ctx commits.
+// Callbacks registered in nested transactions run once the outermost transaction commits, and callbacks
+// registered in an attempt that is retried are discarded. Outside a transaction, fn runs immediately.
+//
+// Example:
+//
+//	err := morm.Transaction(ctx, func(tx context.Context) error {
+//	  if _, err := users.Create(user, tx); err != nil {
+//	    return err
+//	  }
+//	  morm.AfterCommit(tx, func(ctx context.Context) {
+//	    sendWelcomeEmail(ctx, user)
+//	  })
+//	  return nil
+//	})
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		fn(ctx)
+		return
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.afterCommit = append(state.afterCommit, fn)
+}
+
+// AfterRollback registers a callback that runs after the transaction carried by ctx is rolled back
+// or fails to commit. Outside a transaction, fn is never called.
+func AfterRollback(ctx context.Context, fn func(ctx context.Context)) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.afterRollback = append(state.afterRollback, fn)
+}
@@ -0,0 +1,461 @@
+package morm
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TypedCollection is a generics-based handle on a MongoDB collection whose documents decode into T.
+// It is built on the same Collect machinery as the CollectQueryBuilder returned by Collection,
+// but every query starts from a fresh builder, so a TypedCollection can be stored and reused.
+type TypedCollection[T any] struct {
+	c *Collect
+}
+
+// FindQuery is a typed find query whose Exec returns []*T.
+type FindQuery[T any] struct {
+	qb *CollectQueryBuilder
+}
+
+// FindOneQuery is a typed findone query whose Exec returns *T.
+type FindOneQuery[T any] struct {
+	qb *CollectQueryBuilder
+}
+
+// For creates a TypedCollection for the specified collection, decoding documents into T.
+// T must be a struct type. The default connection is used unless a connection is provided.
+//
+// Example:
+//
+//	users, err := morm.For[User]("users")
+//	if err != nil {
+//	  // Handle error
+//	}
+//	list, err := users.Find(bson.M{"active": true}).Limit(10).Exec()
+//
+//	events, err := morm.For[Event]("events", morm.Use("analytics"))
+func For[T any](collectionName string, db ...*DB) (*TypedCollection[T], error) {
+	conn := Use(DefaultConnection)
+	if len(db) > 0 {
+		conn = db[0]
+	}
+
+	qb, err := conn.Collection(collectionName, new(T))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypedCollection[T]{c: qb.c}, nil
+}
+
+// Query returns a new untyped CollectQueryBuilder for the collection.
+// It is useful for operations that are not exposed on the typed API.
+func (tc *TypedCollection[T]) Query() *CollectQueryBuilder {
+	return &CollectQueryBuilder{c: tc.c}
+}
+
+// Pre registers a hook that runs before every operation of the given event on the collection.
+func (tc *TypedCollection[T]) Pre(event string, fn HookFunc) *TypedCollection[T] {
+	tc.c.addHook(true, event, fn)
+	return tc
+}
+
+// Post registers a hook that runs after every successful operation of the given event on the collection.
+func (tc *TypedCollection[T]) Post(event string, fn HookFunc) *TypedCollection[T] {
+	tc.c.addHook(false, event, fn)
+	return tc
+}
+
+// Timestamps sets the timestamp policy of the collection.
+func (tc *TypedCollection[T]) Timestamps(policy TimestampPolicy) *TypedCollection[T] {
+	tc.c.setTimestamps(policy)
+	return tc
+}
+
+// Find starts a typed find query. Additional filter conditions can be provided as optional arguments.
+func (tc *TypedCollection[T]) Find(filter ...interface{}) *FindQuery[T] {
+	return &FindQuery[T]{qb: tc.Query().Find(filter...)}
+}
+
+// FindOne starts a typed findone query with the provided filter.
+func (tc *TypedCollection[T]) FindOne(filter interface{}) *FindOneQuery[T] {
+	return &FindOneQuery[T]{qb: tc.Query().FindOne(filter)}
+}
+
+// FindOneAndUpdate finds a single document based on the filter, updates it and returns the updated document.
+func (tc *TypedCollection[T]) FindOneAndUpdate(filter interface{}, update interface{}, ctx ...context.Context) (*T, error) {
+	result := new(T)
+	_, err := findOneAndUpdate(tc.Query(), filter, update, result, ctx...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// FindOneAndRemove finds a single document based on the filter, removes it and returns the removed document.
+// It returns nil without an error when no document matches the filter.
+func (tc *TypedCollection[T]) FindOneAndRemove(filter interface{}, ctx ...context.Context) (*T, error) {
+	result := new(T)
+	res, err := findOneAndRemove(tc.Query(), filter, result, ctx...)
+	if err != nil || res == nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Create inserts a new document into the collection and returns its ObjectID.
+func (tc *TypedCollection[T]) Create(model *T, ctx ...context.Context) (primitive.ObjectID, error) {
+	return tc.Query().Create(model, ctx...)
+}
+
+// UpdateOne updates a single document in the collection based on the filter and update parameters.
+func (tc *TypedCollection[T]) UpdateOne(filter interface{}, update interface{}, ctx ...context.Context) error {
+	return tc.Query().UpdateOne(filter, update, ctx...)
+}
+
+// Update updates multiple documents in the collection based on the filter and update parameters.
+func (tc *TypedCollection[T]) Update(filter interface{}, update interface{}, ctx ...context.Context) error {
+	return tc.Query().Update(filter, update, ctx...)
+}
+
+// Delete deletes a single document from the collection based on the provided filter.
+func (tc *TypedCollection[T]) Delete(filter interface{}, ctx ...context.Context) error {
+	return tc.Query().Delete(filter, ctx...)
+}
+
+// DeleteMany deletes multiple documents from the collection and returns the number of documents deleted.
+func (tc *TypedCollection[T]) DeleteMany(filter interface{}, ctx ...context.Context) (int64, error) {
+	return tc.Query().DeleteMany(filter, ctx...)
+}
+
+// Skip sets the number of documents to skip in the typed find query.
+func (q *FindQuery[T]) Skip(n int64) *FindQuery[T] {
+	q.qb.Skip(n)
+	return q
+}
+
+// Limit sets the maximum number of documents to return in the typed find query.
+func (q *FindQuery[T]) Limit(n int64) *FindQuery[T] {
+	q.qb.Limit(n)
+	return q
+}
+
+// Projection sets the projection fields for the typed find query.
+func (q *FindQuery[T]) Projection(projection bson.D) *FindQuery[T] {
+	q.qb.Projection(projection)
+	return q
+}
+
+// Sort sets the sort order for the typed find query.
+func (q *FindQuery[T]) Sort(sort bson.D) *FindQuery[T] {
+	q.qb.Sort(sort)
+	return q
+}
+
+// BatchSize sets the number of documents the server returns in each batch of the typed find query.
+func (q *FindQuery[T]) BatchSize(n int32) *FindQuery[T] {
+	q.qb.BatchSize(n)
+	return q
+}
+
+// ResumeAfter makes FindInBatches start after the document with the given _id.
+func (q *FindQuery[T]) ResumeAfter(id interface{}) *FindQuery[T] {
+	q.qb.ResumeAfter(id)
+	return q
+}
+
+// Populate sets the fields to populate in the typed find query result. It accepts the same values as CollectQueryBuilder.Populate.
+func (q *FindQuery[T]) Populate(paths ...interface{}) *FindQuery[T] {
+	q.qb.Populate(paths...)
+	return q
+}
+
+// WithContext sets the context.Context used to run the typed find query.
+func (q *FindQuery[T]) WithContext(ctx context.Context) *FindQuery[T] {
+	q.qb.WithContext(ctx)
+	return q
+}
+
+// Exec executes the typed find query and returns the matching documents.
+func (q *FindQuery[T]) Exec() ([]*T, error) {
+	return q.ExecContext(q.qb.getContext())
+}
+
+// ExecContext executes the typed find query using ctx and returns the matching documents.
+func (q *FindQuery[T]) ExecContext(ctx context.Context) ([]*T, error) {
+	res, err := find(ctx, q.qb, func() interface{} {
+		return new(T)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*T, 0, len(res))
+	for _, r := range res {
+		results = append(results, r.(*T))
+	}
+
+	return results, nil
+}
+
+// FindInBatches walks the documents of the typed find query in batches of size documents in _id order,
+// calling fn with each batch and its number, starting at 1. See CollectQueryBuilder.FindInBatches.
+//
+// Example:
+//
+//	err := users.Find(bson.M{"active": true}).FindInBatches(ctx, 1000, func(batch []*User, n int) error {
+//	  return index(batch)
+//	})
+func (q *FindQuery[T]) FindInBatches(ctx context.Context, size int, fn func(batch []*T, n int) error) error {
+	return findInBatches(ctx, q.qb, size, func() interface{} {
+		return new(T)
+	}, func(batch []interface{}, n int) error {
+		results := make([]*T, 0, len(batch))
+		for _, r := range batch {
+			results = append(results, r.(*T))
+		}
+		return fn(results, n)
+	})
+}
+
+// Where selects the field the following conditions apply to, optionally adding an equality condition.
+func (q *FindQuery[T]) Where(field string, value ...interface{}) *FindQuery[T] {
+	q.qb.Where(field, value...)
+	return q
+}
+
+// Eq adds a condition that the selected field equals value.
+func (q *FindQuery[T]) Eq(value interface{}) *FindQuery[T] {
+	q.qb.Eq(value)
+	return q
+}
+
+// Ne adds a condition that the selected field does not equal value.
+func (q *FindQuery[T]) Ne(value interface{}) *FindQuery[T] {
+	q.qb.Ne(value)
+	return q
+}
+
+// Gt adds a condition that the selected field is greater than value.
+func (q *FindQuery[T]) Gt(value interface{}) *FindQuery[T] {
+	q.qb.Gt(value)
+	return q
+}
+
+// Gte adds a condition that the selected field is greater than or equal to value.
+func (q *FindQuery[T]) Gte(value interface{}) *FindQuery[T] {
+	q.qb.Gte(value)
+	return q
+}
+
+// Lt adds a condition that the selected field is less than value.
+func (q *FindQuery[T]) Lt(value interface{}) *FindQuery[T] {
+	q.qb.Lt(value)
+	return q
+}
+
+// Lte adds a condition that the selected field is less than or equal to value.
+func (q *FindQuery[T]) Lte(value interface{}) *FindQuery[T] {
+	q.qb.Lte(value)
+	return q
+}
+
+// In adds a condition that the selected field equals one of the values.
+func (q *FindQuery[T]) In(values ...interface{}) *FindQuery[T] {
+	q.qb.In(values...)
+	return q
+}
+
+// Nin adds a condition that the selected field equals none of the values.
+func (q *FindQuery[T]) Nin(values ...interface{}) *FindQuery[T] {
+	q.qb.Nin(values...)
+	return q
+}
+
+// Regex adds a condition that the selected field matches the regular expression.
+func (q *FindQuery[T]) Regex(pattern string, options ...string) *FindQuery[T] {
+	q.qb.Regex(pattern, options...)
+	return q
+}
+
+// Exists adds a condition on whether the selected field is present in the document.
+func (q *FindQuery[T]) Exists(exists bool) *FindQuery[T] {
+	q.qb.Exists(exists)
+	return q
+}
+
+// ElemMatch adds a condition that an element of the selected array field matches the filter.
+func (q *FindQuery[T]) ElemMatch(filter interface{}) *FindQuery[T] {
+	q.qb.ElemMatch(filter)
+	return q
+}
+
+// Size adds a condition that the selected array field has exactly n elements.
+func (q *FindQuery[T]) Size(n int) *FindQuery[T] {
+	q.qb.Size(n)
+	return q
+}
+
+// Or adds a condition that at least one of the filters matches.
+func (q *FindQuery[T]) Or(filters ...interface{}) *FindQuery[T] {
+	q.qb.Or(filters...)
+	return q
+}
+
+// Nor adds a condition that none of the filters match.
+func (q *FindQuery[T]) Nor(filters ...interface{}) *FindQuery[T] {
+	q.qb.Nor(filters...)
+	return q
+}
+
+// And adds a condition that all of the filters match.
+func (q *FindQuery[T]) And(filters ...interface{}) *FindQuery[T] {
+	q.qb.And(filters...)
+	return q
+}
+
+// Skip sets the number of documents to skip in the typed findone query.
+func (q *FindOneQuery[T]) Skip(n int64) *FindOneQuery[T] {
+	q.qb.Skip(n)
+	return q
+}
+
+// Projection sets the projection fields for the typed findone query.
+func (q *FindOneQuery[T]) Projection(projection bson.D) *FindOneQuery[T] {
+	q.qb.Projection(projection)
+	return q
+}
+
+// Sort sets the sort order for the typed findone query.
+func (q *FindOneQuery[T]) Sort(sort bson.D) *FindOneQuery[T] {
+	q.qb.Sort(sort)
+	return q
+}
+
+// Populate sets the fields to populate in the typed findone query result. It accepts the same values as CollectQueryBuilder.Populate.
+func (q *FindOneQuery[T]) Populate(paths ...interface{}) *FindOneQuery[T] {
+	q.qb.Populate(paths...)
+	return q
+}
+
+// WithContext sets the context.Context used to run the typed findone query.
+func (q *FindOneQuery[T]) WithContext(ctx context.Context) *FindOneQuery[T] {
+	q.qb.WithContext(ctx)
+	return q
+}
+
+// Exec executes the typed findone query and returns the matching document.
+func (q *FindOneQuery[T]) Exec() (*T, error) {
+	return q.ExecContext(q.qb.getContext())
+}
+
+// ExecContext executes the typed findone query using ctx and returns the matching document.
+func (q *FindOneQuery[T]) ExecContext(ctx context.Context) (*T, error) {
+	result := new(T)
+	_, err := findone(ctx, q.qb, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Where selects the field the following conditions apply to, optionally adding an equality condition.
+func (q *FindOneQuery[T]) Where(field string, value ...interface{}) *FindOneQuery[T] {
+	q.qb.Where(field, value...)
+	return q
+}
+
+// Eq adds a condition that the selected field equals value.
+func (q *FindOneQuery[T]) Eq(value interface{}) *FindOneQuery[T] {
+	q.qb.Eq(value)
+	return q
+}
+
+// Ne adds a condition that the selected field does not equal value.
+func (q *FindOneQuery[T]) Ne(value interface{}) *FindOneQuery[T] {
+	q.qb.Ne(value)
+	return q
+}
+
+// Gt adds a condition that the selected field is greater than value.
+func (q *FindOneQuery[T]) Gt(value interface{}) *FindOneQuery[T] {
+	q.qb.Gt(value)
+	return q
+}
+
+// Gte adds a condition that the selected field is greater than or equal to value.
+func (q *FindOneQuery[T]) Gte(value interface{}) *FindOneQuery[T] {
+	q.qb.Gte(value)
+	return q
+}
+
+// Lt adds a condition that the selected field is less than value.
+func (q *FindOneQuery[T]) Lt(value interface{}) *FindOneQuery[T] {
+	q.qb.Lt(value)
+	return q
+}
+
+// Lte adds a condition that the selected field is less than or equal to value.
+func (q *FindOneQuery[T]) Lte(value interface{}) *FindOneQuery[T] {
+	q.qb.Lte(value)
+	return q
+}
+
+// In adds a condition that the selected field equals one of the values.
+func (q *FindOneQuery[T]) In(values ...interface{}) *FindOneQuery[T] {
+	q.qb.In(values...)
+	return q
+}
+
+// Nin adds a condition that the selected field equals none of the values.
+func (q *FindOneQuery[T]) Nin(values ...interface{}) *FindOneQuery[T] {
+	q.qb.Nin(values...)
+	return q
+}
+
+// Regex adds a condition that the selected field matches the regular expression.
+func (q *FindOneQuery[T]) Regex(pattern string, options ...string) *FindOneQuery[T] {
+	q.qb.Regex(pattern, options...)
+	return q
+}
+
+// Exists adds a condition on whether the selected field is present in the document.
+func (q *FindOneQuery[T]) Exists(exists bool) *FindOneQuery[T] {
+	q.qb.Exists(exists)
+	return q
+}
+
+// ElemMatch adds a condition that an element of the selected array field matches the filter.
+func (q *FindOneQuery[T]) ElemMatch(filter interface{}) *FindOneQuery[T] {
+	q.qb.ElemMatch(filter)
+	return q
+}
+
+// Size adds a condition that the selected array field has exactly n elements.
+func (q *FindOneQuery[T]) Size(n int) *FindOneQuery[T] {
+	q.qb.Size(n)
+	return q
+}
+
+// Or adds a condition that at least one of the filters matches.
+func (q *FindOneQuery[T]) Or(filters ...interface{}) *FindOneQuery[T] {
+	q.qb.Or(filters...)
+	return q
+}
+
+// Nor adds a condition that none of the filters match.
+func (q *FindOneQuery[T]) Nor(filters ...interface{}) *FindOneQuery[T] {
+	q.qb.Nor(filters...)
+	return q
+}
+
+// And adds a condition that all of the filters match.
+func (q *FindOneQuery[T]) And(filters ...interface{}) *FindOneQuery[T] {
+	q.qb.And(filters...)
+	return q
+}
@@ -1,35 +1,67 @@
 package morm
 
 import (
+	"context"
 	"reflect"
+	"sync"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// MongoDB represents the MongoDB client and database information.
-type MongoDB struct {
+// DB represents a MongoDB connection: the client and the database it operates on.
+type DB struct {
 	Client *mongo.Client
 	DBName string
+
+	collectsMu sync.Mutex
+	collects   map[collectKey]*Collect
+	naming     NamingStrategy
+	models     map[reflect.Type]string
+}
+
+// collectKey identifies a Collect by collection name and model type.
+type collectKey struct {
+	name      string
+	modelType reflect.Type
 }
 
+// MongoDB is an alias of DB kept for backwards compatibility.
+type MongoDB = DB
+
 // Collect represents a MongoDB collection and model information.
 type Collect struct {
+	db           *DB
 	collection   *mongo.Collection
 	modelType    reflect.Type
 	modelElemPtr reflect.Value
+
+	mu         sync.RWMutex
+	preHooks   map[string][]HookFunc
+	postHooks  map[string][]HookFunc
+	timestamps *TimestampPolicy
 }
 
 // CollectQueryBuilder represents a query builder for MongoDB operations on a collection.
 type CollectQueryBuilder struct {
-	c          *Collect
-	filter     interface{}
-	skip       int64
-	limit      int64
-	projection bson.D
-	sort       bson.D
-	method     string
-	popFields  []string
-	value      interface{}
-	pre        func(string, func())
+	c           *Collect
+	ctx         context.Context
+	filter      interface{}
+	skip        int64
+	limit       int64
+	batchSize   int32
+	resumeAfter interface{}
+	hint        interface{}
+	collation   *options.Collation
+	projection  bson.D
+	sort        bson.D
+	method      string
+	popPaths    []*PopulatePath
+	value       interface{}
+
+	where        bson.D
+	wherePath    string
+	whereClauses []bson.D
+	whereErr     error
 }
@@ -2,6 +2,9 @@ package morm
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -50,13 +53,19 @@ func ToUpdateStruct(data interface{}) (primitive.M, error) {
 }
 
 // UpdateOne updates a single document in the specified collection based on the filter and update parameters.
+// Pre and post "updateOne" hooks run around the update.
+//
+// The update may be an operator document ($set, $inc, $push, $unset, ...), an UpdateBuilder, a plain struct or document
+// whose fields are all written with $set, or an aggregation pipeline. In every case the "updatedAt"
+// field is merged into the update according to the collection's TimestampPolicy.
 //
 // Parameters:
 //   - filter: The filter criteria to identify the document to update.
 //   - update: The update data to be applied to the document.
+//   - ctx: Optional context for the MongoDB operation.
 //
 // Returns:
-//   - error: An error if the update operation fails.
+//   - error: A *ValidationError if the update writes invalid values, or an error if the update operation fails.
 //
 // Example:
 //
@@ -68,28 +77,35 @@ func ToUpdateStruct(data interface{}) (primitive.M, error) {
 //	}
 //
 // This method is useful for updating a single document in a MongoDB collection.
-func (qb *CollectQueryBuilder) UpdateOne(filter interface{}, update interface{}) error {
+func (qb *CollectQueryBuilder) UpdateOne(filter interface{}, update interface{}, ctx ...context.Context) error {
 	collection := qb.c.collection
+	backgroundContext := qb.getContext(ctx...)
 
-	convertedUpdate, _ := ToUpdateStruct(update)
-	// Set updatedAt field to the current time
-	updateWithUpdatedAt := bson.M{
-		"$set": bson.M{
-			"updatedAt": time.Now(),
-		},
+	op := &Operation{Name: HookUpdateOne, Filter: filter, Update: update}
+	if err := qb.beforeUpdate(backgroundContext, op); err != nil {
+		return err
 	}
+	filter, update = op.Filter, op.Update
 
-	// Merge the provided update with the update for updatedAt
-	for key, value := range convertedUpdate {
-		updateWithUpdatedAt["$set"].(bson.M)[key] = value
+	// Merge the timestamp into the update
+	composedUpdate, err := qb.c.composeUpdate(update)
+	if err != nil {
+		return err
 	}
 
 	// Perform the update
-	_, err := collection.UpdateOne(context.Background(), filter, updateWithUpdatedAt)
-	return err
+	result, err := collection.UpdateOne(backgroundContext, filter, composedUpdate)
+	if err != nil {
+		return err
+	}
+
+	op.Result = result
+	return qb.afterUpdate(backgroundContext, op, update)
 }
 
 // Update updates multiple documents in the specified collection based on the filter and update parameters.
+// Pre and post "update" hooks run around the update.
+// The update is composed like UpdateOne's: operator documents, plain structs and pipelines are accepted.
 //
 // Parameters:
 //   - filter: The filter criteria to identify the documents to update.
@@ -97,7 +113,7 @@ func (qb *CollectQueryBuilder) UpdateOne(filter interface{}, update interface{})
 //   - ctx: Optional context for the MongoDB operation.
 //
 // Returns:
-//   - error: An error if the update operation fails.
+//   - error: A *ValidationError if the update writes invalid values, or an error if the update operation fails.
 //
 // Example:
 //
@@ -111,25 +127,172 @@ func (qb *CollectQueryBuilder) UpdateOne(filter interface{}, update interface{})
 // This method is useful for updating multiple documents in a MongoDB collection.
 func (qb *CollectQueryBuilder) Update(filter interface{}, update interface{}, ctx ...context.Context) error {
 	collection := qb.c.collection
-	var backgroundContext = context.Background()
-	if len(ctx) > 0 {
-		backgroundContext = ctx[0]
+	backgroundContext := qb.getContext(ctx...)
+
+	op := &Operation{Name: HookUpdate, Filter: filter, Update: update}
+	if err := qb.beforeUpdate(backgroundContext, op); err != nil {
+		return err
+	}
+	filter, update = op.Filter, op.Update
+
+	// Merge the timestamp into the update
+	composedUpdate, err := qb.c.composeUpdate(update)
+	if err != nil {
+		return err
 	}
 
-	// Set updatedAt field to the current time
-	updateWithUpdatedAt := bson.M{
-		"$set": bson.M{
-			"updatedAt": time.Now(),
-		},
+	result, err := collection.UpdateMany(backgroundContext, filter, composedUpdate)
+	if err != nil {
+		return err
 	}
 
-	// Merge the provided update with the update for updatedAt
-	updateWithUpdatedAt["$set"].(bson.M)["$set"] = update
+	op.Result = result
+	return qb.afterUpdate(backgroundContext, op, update)
+}
 
-	_, err := collection.UpdateMany(backgroundContext, filter, updateWithUpdatedAt)
+// beforeUpdate merges the builder's Where conditions into the filter of an update operation, runs its pre hooks
+// and the BeforeUpdate hook of the update value, and validates the fields the update writes.
+func (qb *CollectQueryBuilder) beforeUpdate(ctx context.Context, op *Operation) error {
+	filter, err := qb.writeFilter(op.Filter)
 	if err != nil {
 		return err
 	}
+	op.Filter = filter
+
+	if err := qb.c.runHooks(ctx, true, op); err != nil {
+		return err
+	}
+	if err := callModelHook(ctx, op.Update, "BeforeUpdate"); err != nil {
+		return err
+	}
+	return validateUpdate(ctx, qb, op.Update)
+}
+
+// afterUpdate runs the AfterUpdate model hook on the updated value and the post hooks of an update operation.
+func (qb *CollectQueryBuilder) afterUpdate(ctx context.Context, op *Operation, updated interface{}) error {
+	if err := callModelHook(ctx, updated, "AfterUpdate"); err != nil {
+		return err
+	}
+	return qb.c.runHooks(ctx, false, op)
+}
+
+// composeUpdate turns the update passed to UpdateOne, Update or FindOneAndUpdate into the update sent to MongoDB.
+//
+// Parameters:
+//   - update: An operator document, an UpdateBuilder, a plain struct or document, or an aggregation pipeline.
+//
+// Returns:
+//   - interface{}: The update document or pipeline with the updated-at timestamp merged in.
+//   - error: An error if the update mixes operators and plain fields, references an unknown field
+//     through an UpdateBuilder, or cannot be marshaled.
+//
+// Operator documents get the timestamp added to their $set, unless the field is already written by the update.
+// Plain documents are wrapped in $set without their _id and without zero timestamps,
+// so that passing a whole model does not overwrite them. Pipelines get a trailing $set stage.
+func (c *Collect) composeUpdate(update interface{}) (interface{}, error) {
+	policy := c.timestampPolicy()
+
+	if builder, ok := update.(*UpdateBuilder); ok {
+		if err := builder.checkFields(c.modelType); err != nil {
+			return nil, err
+		}
+		update = builder.Document()
+	}
+
+	if stages, ok := toPipeline(update); ok {
+		if !policy.Disabled {
+			stages = append(stages, bson.D{{Key: "$set", Value: bson.D{{Key: policy.UpdatedAt, Value: policy.now()}}}})
+		}
+		return stages, nil
+	}
+
+	doc, err := toDocument(update)
+	if err != nil {
+		return nil, err
+	}
+
+	operators := 0
+	for _, elem := range doc {
+		if strings.HasPrefix(elem.Key, "$") {
+			operators++
+		}
+	}
+
+	if operators > 0 && operators < len(doc) {
+		return nil, errors.New("update must not mix operators and fields")
+	}
+
+	if operators == 0 {
+		fields := bson.D{}
+		for _, elem := range doc {
+			isTimestamp := elem.Key == policy.CreatedAt || elem.Key == policy.UpdatedAt
+			if elem.Key == "_id" || (isTimestamp && isZeroDateTime(elem.Value)) {
+				continue
+			}
+			fields = append(fields, elem)
+		}
+		doc = bson.D{{Key: "$set", Value: fields}}
+	}
+
+	if policy.Disabled || writesField(doc, policy.UpdatedAt) {
+		return doc, nil
+	}
+
+	// Copy the document and its $set, which may be the caller's own values
+	doc = append(bson.D(nil), doc...)
+	now := bson.E{Key: policy.UpdatedAt, Value: policy.now()}
+	for i, elem := range doc {
+		if elem.Key != "$set" {
+			continue
+		}
+		set, err := toDocument(elem.Value)
+		if err != nil {
+			return nil, err
+		}
+		doc[i].Value = append(append(bson.D(nil), set...), now)
+		return doc, nil
+	}
+
+	return append(doc, bson.E{Key: "$set", Value: bson.D{now}}), nil
+}
+
+// toPipeline returns the stages of an aggregation pipeline update, reporting false for update documents.
+func toPipeline(update interface{}) ([]interface{}, bool) {
+	switch update.(type) {
+	case bson.D, bson.Raw, []byte:
+		return nil, false
+	}
+
+	value := reflect.ValueOf(update)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	stages := make([]interface{}, 0, value.Len()+1)
+	for i := 0; i < value.Len(); i++ {
+		stages = append(stages, value.Index(i).Interface())
+	}
+	return stages, true
+}
+
+// writesField reports whether any operator of an update document already writes the field.
+func writesField(doc bson.D, field string) bool {
+	for _, elem := range doc {
+		fields, err := toDocument(elem.Value)
+		if err != nil {
+			continue
+		}
+		for _, f := range fields {
+			if f.Key == field {
+				return true
+			}
+		}
+	}
+	return false
+}
 
-	return nil
+// isZeroDateTime reports whether a marshaled value is the BSON encoding of the zero time.Time.
+func isZeroDateTime(v interface{}) bool {
+	dateTime, ok := v.(primitive.DateTime)
+	return ok && dateTime == primitive.NewDateTimeFromTime(time.Time{})
 }
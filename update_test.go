@@ -0,0 +1,113 @@
+package morm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestComposeUpdate tests the update documents sent to MongoDB for each kind of update
+func TestComposeUpdate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	stamp := bson.E{Key: "updatedAt", Value: now}
+	id := primitive.NewObjectID()
+
+	tests := []struct {
+		name     string
+		disabled bool
+		update   interface{}
+		composed interface{}
+	}{
+		{
+			name:     "operators",
+			update:   bson.M{"$inc": bson.M{"name": 1}},
+			composed: bson.D{{Key: "$inc", Value: bson.D{{Key: "name", Value: int32(1)}}}, {Key: "$set", Value: bson.D{stamp}}},
+		},
+		{
+			name:     "operators with $set",
+			update:   bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "a"}}}},
+			composed: bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "a"}, stamp}}},
+		},
+		{
+			name:     "operators writing updatedAt",
+			update:   bson.D{{Key: "$currentDate", Value: bson.D{{Key: "updatedAt", Value: true}}}},
+			composed: bson.D{{Key: "$currentDate", Value: bson.D{{Key: "updatedAt", Value: true}}}},
+		},
+		{
+			name:     "fields",
+			update:   bson.D{{Key: "_id", Value: id}, {Key: "name", Value: "a"}},
+			composed: bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "a"}, stamp}}},
+		},
+		{
+			name:     "struct",
+			update:   replaced{Name: "a"},
+			composed: bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "a"}, stamp}}},
+		},
+		{
+			name:     "pipeline",
+			update:   bson.A{bson.D{{Key: "$unset", Value: "name"}}},
+			composed: []interface{}{bson.D{{Key: "$unset", Value: "name"}}, bson.D{{Key: "$set", Value: bson.D{stamp}}}},
+		},
+		{
+			name:     "builder",
+			update:   Set("name", "a"),
+			composed: bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "a"}, stamp}}},
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			update:   bson.D{{Key: "name", Value: "a"}},
+			composed: bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "a"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Collect{
+				modelType:  reflect.TypeOf(&replaced{}),
+				timestamps: &TimestampPolicy{Disabled: tt.disabled, Now: func() time.Time { return now }},
+			}
+
+			composed, err := c.composeUpdate(tt.update)
+			if err != nil {
+				t.Fatalf("Failed to compose update: %v", err)
+			}
+			if !reflect.DeepEqual(composed, tt.composed) {
+				t.Fatalf("Expected %v, got %v", tt.composed, composed)
+			}
+		})
+	}
+}
+
+// TestComposeUpdateErrors tests that mixed updates and unknown builder fields are rejected
+func TestComposeUpdateErrors(t *testing.T) {
+	c := &Collect{modelType: reflect.TypeOf(&replaced{})}
+
+	if _, err := c.composeUpdate(bson.D{{Key: "$set", Value: bson.D{}}, {Key: "name", Value: "a"}}); err == nil {
+		t.Fatal("Expected an update mixing operators and fields to be rejected")
+	}
+	if _, err := c.composeUpdate(Set("missing", 1)); !errors.Is(err, ErrUnknownField) {
+		t.Fatalf("Expected ErrUnknownField, got %v", err)
+	}
+}
+
+// TestComposeUpdateReusesDocument tests that the updatedAt timestamp is not written into the caller's update document
+func TestComposeUpdateReusesDocument(t *testing.T) {
+	c := &Collect{}
+
+	set := bson.D{{Key: "name", Value: "a"}}
+	update := bson.D{{Key: "$set", Value: set}}
+	for i := 0; i < 2; i++ {
+		if _, err := c.composeUpdate(update); err != nil {
+			t.Fatalf("Failed to compose update: %v", err)
+		}
+	}
+
+	expected := bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "a"}}}}
+	if !reflect.DeepEqual(update, expected) || len(set) != 1 {
+		t.Fatalf("Expected the update to be left unchanged, got %v", update)
+	}
+}
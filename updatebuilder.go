@@ -0,0 +1,171 @@
+package morm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrUnknownField is returned when a builder references a field that does not exist in the model's bson tags.
+var ErrUnknownField = errors.New("unknown field")
+
+// UpdateBuilder builds an update document with typed, chainable operators.
+// It can be passed to UpdateOne, Update and FindOneAndUpdate, which check every field against the
+// model's bson tags and merge the "updatedAt" timestamp into it.
+//
+// Example:
+//
+//	update := morm.Set("name", "Ada").Inc("views", 1).Push("tags", "go").Unset("draft")
+//	err := qb.UpdateOne(bson.M{"_id": id}, update)
+type UpdateBuilder struct {
+	operators bson.D
+}
+
+// Set starts an update builder that sets a field to a value.
+func Set(field string, value interface{}) *UpdateBuilder {
+	return new(UpdateBuilder).Set(field, value)
+}
+
+// SetOnInsert starts an update builder that sets a field only when an upsert inserts a document.
+func SetOnInsert(field string, value interface{}) *UpdateBuilder {
+	return new(UpdateBuilder).SetOnInsert(field, value)
+}
+
+// Inc starts an update builder that increments a numeric field.
+func Inc(field string, amount interface{}) *UpdateBuilder {
+	return new(UpdateBuilder).Inc(field, amount)
+}
+
+// Push starts an update builder that appends a value to an array field.
+func Push(field string, value interface{}) *UpdateBuilder {
+	return new(UpdateBuilder).Push(field, value)
+}
+
+// AddToSet starts an update builder that adds a value to an array field unless it is already present.
+func AddToSet(field string, value interface{}) *UpdateBuilder {
+	return new(UpdateBuilder).AddToSet(field, value)
+}
+
+// Pull starts an update builder that removes matching values from an array field.
+func Pull(field string, condition interface{}) *UpdateBuilder {
+	return new(UpdateBuilder).Pull(field, condition)
+}
+
+// Unset starts an update builder that removes fields from the document.
+func Unset(fields ...string) *UpdateBuilder {
+	return new(UpdateBuilder).Unset(fields...)
+}
+
+// CurrentDate starts an update builder that sets fields to the current server date.
+func CurrentDate(fields ...string) *UpdateBuilder {
+	return new(UpdateBuilder).CurrentDate(fields...)
+}
+
+// Set sets a field to a value with $set.
+func (u *UpdateBuilder) Set(field string, value interface{}) *UpdateBuilder {
+	return u.add("$set", field, value)
+}
+
+// SetOnInsert sets a field with $setOnInsert, only when an upsert inserts a document.
+func (u *UpdateBuilder) SetOnInsert(field string, value interface{}) *UpdateBuilder {
+	return u.add("$setOnInsert", field, value)
+}
+
+// Inc increments a numeric field by amount with $inc.
+func (u *UpdateBuilder) Inc(field string, amount interface{}) *UpdateBuilder {
+	return u.add("$inc", field, amount)
+}
+
+// Push appends a value to an array field with $push.
+func (u *UpdateBuilder) Push(field string, value interface{}) *UpdateBuilder {
+	return u.add("$push", field, value)
+}
+
+// AddToSet adds a value to an array field with $addToSet unless it is already present.
+func (u *UpdateBuilder) AddToSet(field string, value interface{}) *UpdateBuilder {
+	return u.add("$addToSet", field, value)
+}
+
+// Pull removes the values matching condition from an array field with $pull.
+// The condition is either a value or a query document such as bson.M{"$lt": 5}.
+func (u *UpdateBuilder) Pull(field string, condition interface{}) *UpdateBuilder {
+	return u.add("$pull", field, condition)
+}
+
+// Unset removes fields from the document with $unset.
+func (u *UpdateBuilder) Unset(fields ...string) *UpdateBuilder {
+	for _, field := range fields {
+		u.add("$unset", field, "")
+	}
+	return u
+}
+
+// CurrentDate sets fields to the current server date with $currentDate.
+func (u *UpdateBuilder) CurrentDate(fields ...string) *UpdateBuilder {
+	for _, field := range fields {
+		u.add("$currentDate", field, true)
+	}
+	return u
+}
+
+// Document returns the update document built so far.
+func (u *UpdateBuilder) Document() bson.D {
+	doc := make(bson.D, 0, len(u.operators))
+	for _, operator := range u.operators {
+		fields := operator.Value.(bson.D)
+		doc = append(doc, bson.E{Key: operator.Key, Value: append(bson.D{}, fields...)})
+	}
+	return doc
+}
+
+// MarshalBSON encodes the update document, so an UpdateBuilder can also be passed to the driver directly.
+func (u *UpdateBuilder) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(u.Document())
+}
+
+// add records a field of an operator, replacing the value if the field was already set for that operator.
+func (u *UpdateBuilder) add(operator string, field string, value interface{}) *UpdateBuilder {
+	for i, elem := range u.operators {
+		if elem.Key != operator {
+			continue
+		}
+
+		fields := elem.Value.(bson.D)
+		for j := range fields {
+			if fields[j].Key == field {
+				fields[j].Value = value
+				return u
+			}
+		}
+		u.operators[i].Value = append(fields, bson.E{Key: field, Value: value})
+		return u
+	}
+
+	u.operators = append(u.operators, bson.E{Key: operator, Value: bson.D{{Key: field, Value: value}}})
+	return u
+}
+
+// checkFields returns an ErrUnknownField error for the first field that does not exist in the model.
+func (u *UpdateBuilder) checkFields(modelType reflect.Type) error {
+	for _, operator := range u.operators {
+		for _, field := range operator.Value.(bson.D) {
+			if err := checkField(modelType, field.Key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkField returns an ErrUnknownField error if the dotted bson path does not exist in the model.
+func checkField(modelType reflect.Type, path string) error {
+	if path == "_id" {
+		return nil
+	}
+	if _, ok := lookupField(modelType, path); !ok {
+		return fmt.Errorf("%w %q in %s", ErrUnknownField, path, elemType(modelType).Name())
+	}
+	return nil
+}
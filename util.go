@@ -3,7 +3,10 @@ package morm
 import (
 	"errors"
 	"reflect"
+	"strconv"
 	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 // getModelType returns the reflect.Type of the model pointed to by the input pointer.
@@ -79,27 +82,28 @@ func getFirstStringAfterSplit(input string) string {
 func getTags(modelType reflect.Type, field string) (map[string]string, error) {
 	fieldTags := make(map[string]string)
 
-	for i := 0; i < modelType.NumField(); i++ {
-		structField := modelType.Field(i)
-
-		if structField.Name == field {
-			localFieldTag := structField.Tag.Get("localField")
-			foreignFieldTag := structField.Tag.Get("foreignField")
-			justOneTag := structField.Tag.Get("justOne")
-			countTag := structField.Tag.Get("count")
-			jsonTag := structField.Tag.Get("json")
+	structField, ok := modelType.FieldByName(field)
+	if !ok {
+		return nil, errors.New("field not found")
+	}
 
-			fieldTags["localField"] = localFieldTag
-			fieldTags["foreignField"] = foreignFieldTag
-			fieldTags["justOne"] = justOneTag
-			fieldTags["count"] = countTag
-			fieldTags["json"] = jsonTag
+	localFieldTag := structField.Tag.Get("localField")
+	foreignFieldTag := structField.Tag.Get("foreignField")
+	justOneTag := structField.Tag.Get("justOne")
+	countTag := structField.Tag.Get("count")
+	jsonTag := structField.Tag.Get("json")
+	refPathTag := structField.Tag.Get("refPath")
+	bsonName, _, _ := bsonFieldName(structField)
 
-			return fieldTags, nil
-		}
-	}
+	fieldTags["localField"] = localFieldTag
+	fieldTags["foreignField"] = foreignFieldTag
+	fieldTags["justOne"] = justOneTag
+	fieldTags["count"] = countTag
+	fieldTags["json"] = jsonTag
+	fieldTags["refPath"] = refPathTag
+	fieldTags["bson"] = bsonName
 
-	return nil, errors.New("field not found")
+	return fieldTags, nil
 }
 
 // removeBrackets removes "[" and "]" from the input string.
@@ -121,3 +125,205 @@ func removeBrackets(input string) string {
 	result := strings.ReplaceAll(strings.ReplaceAll(input, "[", ""), "]", "")
 	return result
 }
+
+// bsonFieldName returns the name a struct field is stored under, following the driver's defaults.
+//
+// Parameters:
+//   - structField: The struct field to inspect.
+//
+// Returns:
+//   - string: The bson key, taken from the bson tag or the lowercased field name.
+//   - bool: Whether the field is inlined into its parent document.
+//   - bool: Whether the field is skipped with bson:"-".
+//
+// Example:
+//
+//	field, _ := reflect.TypeOf(User{}).FieldByName("Email")
+//	name, inline, skip := bsonFieldName(field)
+//
+// This function is useful for mapping Go struct fields to their MongoDB document keys.
+func bsonFieldName(structField reflect.StructField) (string, bool, bool) {
+	tag := structField.Tag.Get("bson")
+	name := getFirstStringAfterSplit(tag)
+	if name == "-" {
+		return "", false, true
+	}
+
+	inline := false
+	for _, opt := range strings.Split(tag, ",")[1:] {
+		if opt == "inline" {
+			inline = true
+		}
+	}
+
+	if name == "" {
+		name = strings.ToLower(structField.Name)
+	}
+	return name, inline, false
+}
+
+// lookupField finds the struct field stored under a dotted bson path of a model.
+//
+// Parameters:
+//   - modelType: The reflect.Type of the model (struct or pointer to struct).
+//   - path: The dotted bson path, e.g. "address.city".
+//
+// Returns:
+//   - reflect.StructField: The field at the end of the path.
+//   - bool: Whether the path exists in the model.
+//
+// Example:
+//
+//	field, ok := lookupField(reflect.TypeOf(User{}), "address.city")
+//
+// Array indexes and positional operators such as "items.$.sku" or "items.0.sku" are stepped over.
+func lookupField(modelType reflect.Type, path string) (reflect.StructField, bool) {
+	current := modelType
+	var found reflect.StructField
+
+	for i, segment := range strings.Split(path, ".") {
+		current = elemType(current)
+		if i > 0 && isPositional(segment) {
+			continue
+		}
+		if current.Kind() != reflect.Struct {
+			return reflect.StructField{}, false
+		}
+
+		structField, ok := fieldByBSONName(current, segment)
+		if !ok {
+			return reflect.StructField{}, false
+		}
+		found = structField
+		current = structField.Type
+	}
+
+	return found, found.Name != ""
+}
+
+// fieldByBSONName finds the field of a struct type stored under the bson key, searching inlined structs.
+func fieldByBSONName(structType reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name, inline, skip := bsonFieldName(structField)
+		if skip {
+			continue
+		}
+		if inline {
+			if inlineType := elemType(structField.Type); inlineType.Kind() == reflect.Struct {
+				if nested, ok := fieldByBSONName(inlineType, key); ok {
+					return nested, true
+				}
+			}
+			continue
+		}
+		if name == key {
+			return structField, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// fieldValueByBSONName returns the field of a struct value stored under the bson key, searching inlined structs.
+//
+// Parameters:
+//   - value: The struct value to search.
+//   - key: The bson key of the field.
+//
+// Returns:
+//   - reflect.Value: The field value, settable when the struct is addressable.
+//   - bool: Whether the field exists.
+func fieldValueByBSONName(value reflect.Value, key string) (reflect.Value, bool) {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		structField := valueType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name, inline, skip := bsonFieldName(structField)
+		if skip {
+			continue
+		}
+		if inline {
+			inlined := value.Field(i)
+			if inlined.Kind() == reflect.Ptr {
+				if inlined.IsNil() {
+					continue
+				}
+				inlined = inlined.Elem()
+			}
+			if inlined.Kind() == reflect.Struct {
+				if field, ok := fieldValueByBSONName(inlined, key); ok {
+					return field, true
+				}
+			}
+			continue
+		}
+		if name == key {
+			return value.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// elemType strips pointers, slices, arrays and maps from a type to reach the element stored in it.
+func elemType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// isPositional reports whether a path segment is an array index or an update positional operator.
+func isPositional(segment string) bool {
+	if strings.HasPrefix(segment, "$") {
+		return true
+	}
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
+
+// joinPath joins two segments of a dotted path, omitting the dot when the prefix is empty.
+func joinPath(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// toDocument converts a document (struct, map, bson.M or bson.D) into an ordered bson.D.
+//
+// Parameters:
+//   - v: The value to convert.
+//
+// Returns:
+//   - bson.D: The converted document.
+//   - error: An error if the value cannot be marshaled as a document.
+//
+// This function is useful for inspecting the keys of an arbitrary filter or update document.
+func toDocument(v interface{}) (bson.D, error) {
+	if doc, ok := v.(bson.D); ok {
+		return doc, nil
+	}
+
+	data, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bson.D
+	err = bson.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
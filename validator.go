@@ -1,5 +1,590 @@
 package morm
 
-func validate() {
-	
-}
\ No newline at end of file
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ValidationError is returned when a document fails validation.
+// It lists every failing field rather than stopping at the first one.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+// FieldError describes a single validation rule that failed on a field.
+type FieldError struct {
+	// Path is the dotted bson path of the field, e.g. "address.city" or "items.2.sku".
+	Path string
+	// Rule is the name of the failing rule, e.g. "required" or "min".
+	Rule string
+	// Param is the rule parameter from the tag, e.g. "3" for min=3.
+	Param string
+	// Message is a human readable description of the failure.
+	Message string
+}
+
+// Error returns the failing field paths and their messages.
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		messages = append(messages, fieldErr.Error())
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Error returns the field path followed by the failure message.
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validator is implemented by models that validate themselves, typically for cross-field rules
+// such as "endDate must be after startDate". Validate is called after the morm tag rules of the
+// struct have been checked, for the model and for every nested struct implementing it.
+// Returned errors are merged into the aggregated *ValidationError.
+// During writes, CollectionFromContext(ctx) returns a builder on the collection being written.
+type Validator interface {
+	Validate(ctx context.Context) error
+}
+
+// ValidatorFunc is a custom validation rule registered with RegisterValidator.
+// It returns nil when the field is valid. A *FieldError or *ValidationError is merged as is;
+// any other error becomes the message of a FieldError for the field.
+type ValidatorFunc func(ctx context.Context, field *FieldValue) error
+
+// FieldValue describes the field a custom validation rule is applied to.
+type FieldValue struct {
+	// Path is the dotted bson path of the field.
+	Path string
+	// Value is the value of the field.
+	Value interface{}
+	// Param is the rule parameter from the tag, e.g. "startDate" for after=startDate.
+	Param string
+	// Parent is the struct holding the field. It is invalid when validating update documents.
+	Parent reflect.Value
+	// Query is a fresh builder on the collection being written, or nil outside of a write.
+	Query *CollectQueryBuilder
+}
+
+// rule is a single parsed entry of a morm struct tag.
+type rule struct {
+	name  string
+	param string
+}
+
+// builtinRules lists the rule names handled by checkRule.
+var builtinRules = map[string]bool{"required": true, "min": true, "max": true, "enum": true, "match": true}
+
+var (
+	validatorsMu sync.RWMutex
+	validators   = make(map[string]ValidatorFunc)
+)
+
+// regexCache holds compiled match patterns keyed by their source.
+var regexCache sync.Map
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// collectionContextKey is the context key under which the builder being written is stored.
+type collectionContextKey struct{}
+
+// validation carries the state of a single validation run.
+type validation struct {
+	ctx  context.Context
+	qb   *CollectQueryBuilder
+	errs []*FieldError
+}
+
+// RegisterValidator registers a custom rule usable from morm struct tags under the given name.
+// Registering a name again replaces the previous rule; built-in rule names cannot be overridden.
+//
+// Example:
+//
+//	morm.RegisterValidator("unique", func(ctx context.Context, f *morm.FieldValue) error {
+//	  _, err := f.Query.FindOne(bson.M{f.Path: f.Value}).ExecContext(ctx)
+//	  if errors.Is(err, mongo.ErrNoDocuments) {
+//	    return nil
+//	  }
+//	  if err != nil {
+//	    return err
+//	  }
+//	  return errors.New("is already taken")
+//	})
+//
+//	type User struct {
+//	  Email string `bson:"email" morm:"required,unique"`
+//	}
+func RegisterValidator(name string, fn ValidatorFunc) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+
+	validators[name] = fn
+}
+
+// CollectionFromContext returns the builder on the collection being written during validation,
+// or nil when the context does not come from a morm write.
+func CollectionFromContext(ctx context.Context) *CollectQueryBuilder {
+	qb, _ := ctx.Value(collectionContextKey{}).(*CollectQueryBuilder)
+	return qb
+}
+
+// Validate checks a struct, or a pointer to a struct, against the rules declared in its morm tags.
+// Nested structs, pointers to structs and slices of structs are validated recursively.
+//
+// Supported rules:
+//   - required: the field must not be its zero value.
+//   - min=n, max=n: bounds on numbers, and on the length of strings, slices and maps.
+//   - enum=a|b|c: the field must equal one of the listed values.
+//   - match=pattern: string fields must match the regular expression. It must be the last rule in the tag.
+//   - any name registered with RegisterValidator, with an optional name=param parameter.
+//
+// Rules other than required are skipped for nil pointers, slices, maps and interfaces, for empty strings
+// under enum and match, and for fields that already failed required. Zero numbers and bools are checked,
+// so min=1 rejects 0. Structs implementing Validator are checked last.
+//
+// Parameters:
+//   - v: The struct or pointer to a struct to validate.
+//   - ctx: Optional context.Context passed to custom validators. If not provided, the default context will be used.
+//
+// Returns:
+//   - error: A *ValidationError listing every failing field, or nil if the value is valid.
+//
+// Example:
+//
+//	type User struct {
+//	  Name string `bson:"name" morm:"required,min=3,max=64"`
+//	  Role string `bson:"role" morm:"enum=admin|member"`
+//	  Slug string `bson:"slug" morm:"match=^[a-z]+$"`
+//	}
+//
+//	err := morm.Validate(&User{Name: "Al"})
+func Validate(v interface{}, ctx ...context.Context) error {
+	var backgroundContext = context.Background()
+	if len(ctx) > 0 {
+		backgroundContext = ctx[0]
+	}
+	return validateDocument(backgroundContext, nil, v)
+}
+
+// validateDocument validates a struct written through the builder, or outside of a write when qb is nil.
+func validateDocument(ctx context.Context, qb *CollectQueryBuilder, v interface{}) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	run := newValidation(ctx, qb)
+	run.validateStruct(value, "")
+	return newValidationError(run.errs)
+}
+
+// newValidation starts a validation run, exposing a fresh builder on the collection to validators.
+func newValidation(ctx context.Context, qb *CollectQueryBuilder) *validation {
+	if qb == nil {
+		return &validation{ctx: ctx}
+	}
+
+	fresh := &CollectQueryBuilder{c: qb.c, ctx: ctx}
+	return &validation{ctx: context.WithValue(ctx, collectionContextKey{}, fresh), qb: fresh}
+}
+
+// newValidationError wraps the collected field errors, returning nil when there are none.
+func newValidationError(errs []*FieldError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: errs}
+}
+
+// validateStruct applies the morm tag rules of every field of a struct value, recurses into nested values
+// and finally calls the struct's Validator implementation.
+func (v *validation) validateStruct(value reflect.Value, prefix string) {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		structField := valueType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name, inline, skip := bsonFieldName(structField)
+		if skip {
+			continue
+		}
+
+		path := prefix
+		if !inline {
+			path = joinPath(prefix, name)
+		}
+
+		v.validateValue(value, value.Field(i), path, parseRules(structField.Tag.Get("morm")))
+	}
+
+	target := value
+	if value.CanAddr() {
+		target = value.Addr()
+	}
+	if validator, ok := target.Interface().(Validator); ok {
+		v.merge(prefix, "validate", validator.Validate(v.ctx))
+	}
+}
+
+// validateValue applies rules to a single value and recurses into structs, pointers and slices.
+func (v *validation) validateValue(parent reflect.Value, value reflect.Value, path string, rules []rule) {
+	for _, r := range rules {
+		if r.name == "default" || (r.name != "required" && skipRule(value, r)) {
+			continue
+		}
+
+		if !builtinRules[r.name] {
+			v.runCustom(parent, value, path, r)
+			continue
+		}
+
+		if fieldErr := checkRule(value, r); fieldErr != nil {
+			fieldErr.Path = path
+			v.errs = append(v.errs, fieldErr)
+			if r.name == "required" {
+				break
+			}
+		}
+	}
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if value.Type() != timeType {
+			v.validateStruct(value, path)
+		}
+	case reflect.Slice, reflect.Array:
+		if !mayContainStruct(value.Type().Elem()) {
+			return
+		}
+		for i := 0; i < value.Len(); i++ {
+			v.validateValue(value, value.Index(i), joinPath(path, strconv.Itoa(i)), nil)
+		}
+	}
+}
+
+// runCustom applies a rule registered with RegisterValidator.
+func (v *validation) runCustom(parent reflect.Value, value reflect.Value, path string, r rule) {
+	validatorsMu.RLock()
+	fn, ok := validators[r.name]
+	validatorsMu.RUnlock()
+
+	if !ok {
+		v.errs = append(v.errs, &FieldError{Path: path, Rule: r.name, Param: r.param, Message: fmt.Sprintf("uses unknown rule %q", r.name)})
+		return
+	}
+
+	var fieldValue interface{}
+	if value.IsValid() && value.CanInterface() {
+		fieldValue = value.Interface()
+	}
+
+	v.merge(path, r.name, fn(v.ctx, &FieldValue{
+		Path:   path,
+		Value:  fieldValue,
+		Param:  r.param,
+		Parent: parent,
+		Query:  v.qb,
+	}))
+}
+
+// merge adds an error returned by a custom validator to the run.
+// Nested paths of *ValidationError and *FieldError values are prefixed with the path they were returned for.
+func (v *validation) merge(path string, ruleName string, err error) {
+	if err == nil {
+		return
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		for _, fieldErr := range validationErr.Errors {
+			merged := *fieldErr
+			merged.Path = joinPath(path, fieldErr.Path)
+			v.errs = append(v.errs, &merged)
+		}
+		return
+	}
+
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		merged := *fieldErr
+		merged.Path = joinPath(path, fieldErr.Path)
+		if merged.Rule == "" {
+			merged.Rule = ruleName
+		}
+		v.errs = append(v.errs, &merged)
+		return
+	}
+
+	v.errs = append(v.errs, &FieldError{Path: path, Rule: ruleName, Message: err.Error()})
+}
+
+// checkRule evaluates a single rule against a value and returns a FieldError without a path if it fails.
+func checkRule(value reflect.Value, r rule) *FieldError {
+	present := !isNil(value) && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			break
+		}
+		value = value.Elem()
+	}
+
+	fail := func(format string, args ...interface{}) *FieldError {
+		return &FieldError{Rule: r.name, Param: r.param, Message: fmt.Sprintf(format, args...)}
+	}
+
+	switch r.name {
+	case "required":
+		if present {
+			return nil
+		}
+		if !value.IsValid() || value.IsZero() || (isSized(value) && value.Len() == 0) {
+			return fail("is required")
+		}
+	case "min", "max":
+		bound, err := strconv.ParseFloat(r.param, 64)
+		if err != nil {
+			return fail("has an invalid %s rule %q", r.name, r.param)
+		}
+		size, isLength, ok := measure(value)
+		if !ok {
+			return nil
+		}
+		if r.name == "min" && size < bound {
+			if isLength {
+				return fail("must have a length of at least %s", r.param)
+			}
+			return fail("must be at least %s", r.param)
+		}
+		if r.name == "max" && size > bound {
+			if isLength {
+				return fail("must have a length of at most %s", r.param)
+			}
+			return fail("must be at most %s", r.param)
+		}
+	case "enum":
+		actual := fmt.Sprint(value.Interface())
+		for _, option := range strings.Split(r.param, "|") {
+			if actual == option {
+				return nil
+			}
+		}
+		return fail("must be one of %s", strings.ReplaceAll(r.param, "|", ", "))
+	case "match":
+		if value.Kind() != reflect.String {
+			return nil
+		}
+		pattern, err := compilePattern(r.param)
+		if err != nil {
+			return fail("has an invalid match pattern %q", r.param)
+		}
+		if !pattern.MatchString(value.String()) {
+			return fail("must match %s", r.param)
+		}
+	}
+
+	return nil
+}
+
+// validateUpdate validates the fields an update written through the builder would write against the
+// model's morm tags. Structs are validated in full; for update documents, the fields of $set and
+// $setOnInsert (or of a plain replacement document) are validated individually. Pipelines are not validated.
+// An update that cannot be converted to a document is returned as an error.
+func validateUpdate(ctx context.Context, qb *CollectQueryBuilder, update interface{}) error {
+	if builder, ok := update.(*UpdateBuilder); ok {
+		update = builder.Document()
+	}
+
+	value := reflect.ValueOf(update)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Struct {
+		return validateDocument(ctx, qb, update)
+	}
+
+	if _, ok := toPipeline(update); ok {
+		return nil
+	}
+
+	doc, err := toDocument(update)
+	if err != nil {
+		return err
+	}
+
+	run := newValidation(ctx, qb)
+	for _, elem := range doc {
+		switch {
+		case elem.Key == "$set" || elem.Key == "$setOnInsert":
+			fields, err := toDocument(elem.Value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", elem.Key, err)
+			}
+			for _, field := range fields {
+				run.validateFieldValue(qb.c.modelType, field.Key, field.Value)
+			}
+		case !strings.HasPrefix(elem.Key, "$"):
+			run.validateFieldValue(qb.c.modelType, elem.Key, elem.Value)
+		}
+	}
+
+	return newValidationError(run.errs)
+}
+
+// validateFieldValue validates a value written to the bson path of a model.
+// Values for struct fields are decoded into the field type so nested rules apply.
+func (v *validation) validateFieldValue(modelType reflect.Type, path string, raw interface{}) {
+	structField, ok := lookupField(modelType, path)
+	if !ok {
+		return
+	}
+
+	rules := parseRules(structField.Tag.Get("morm"))
+	fieldType := structField.Type
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	value := reflect.ValueOf(raw)
+	if fieldType.Kind() == reflect.Struct && fieldType != timeType && raw != nil {
+		target := reflect.New(fieldType)
+		if data, err := bson.Marshal(raw); err == nil && bson.Unmarshal(data, target.Interface()) == nil {
+			value = target
+		}
+	}
+
+	v.validateValue(reflect.Value{}, value, path, rules)
+}
+
+// parseRules parses a morm struct tag into rules.
+// Everything after "match=" is treated as the pattern so it may contain commas.
+func parseRules(tag string) []rule {
+	var rules []rule
+	for tag != "" {
+		if strings.HasPrefix(tag, "match=") {
+			rules = append(rules, rule{name: "match", param: strings.TrimPrefix(tag, "match=")})
+			break
+		}
+
+		part := tag
+		if i := strings.Index(tag, ","); i >= 0 {
+			part, tag = tag[:i], tag[i+1:]
+		} else {
+			tag = ""
+		}
+
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(part, "=")
+		rules = append(rules, rule{name: name, param: param})
+	}
+	return rules
+}
+
+// compilePattern compiles a match pattern, caching the result.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := regexCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Store(pattern, compiled)
+	return compiled, nil
+}
+
+// measure returns the numeric size used by min and max: the value of numbers and the length of
+// strings, slices, arrays and maps. isLength reports whether the size is a length.
+func measure(value reflect.Value) (size float64, isLength bool, ok bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), false, true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), true, true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), true, true
+	}
+	return 0, false, false
+}
+
+// mayContainStruct reports whether values of the type can hold structs that need validation.
+func mayContainStruct(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return (t.Kind() == reflect.Struct && t != timeType) || t.Kind() == reflect.Interface
+}
+
+// isSized reports whether the value has a length.
+func isSized(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// skipRule reports whether a rule other than required does not apply to the value:
+// absent values are optional, and so are empty strings for enum and match.
+func skipRule(value reflect.Value, r rule) bool {
+	if isNil(value) {
+		return true
+	}
+	if r.name != "enum" && r.name != "match" {
+		return false
+	}
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return true
+		}
+		value = value.Elem()
+	}
+	return value.Kind() == reflect.String && value.Len() == 0
+}
+
+// isNil reports whether the value is absent: invalid, or a nil pointer, interface, slice or map.
+func isNil(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return value.IsNil()
+	}
+	return false
+}
@@ -2,8 +2,8 @@ package morm
 
 import (
 	"context"
+	"fmt"
 	"reflect"
-	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -13,77 +13,201 @@ import (
 //
 // The virtual lookup involves creating a $lookup stage for each field, linking to the related collection,
 // and updating the value based on the specified local and foreign fields. If the field has "justOne" set to true,
-// it uses $arrayElemAt to ensure a single value is returned.
+// it uses $arrayElemAt to ensure a single value is returned. If the field has "count" set to true, it is set to the
+// number of related documents instead.
+//
+// The related collection is taken from the field's ref tag, then from the collection the field's model type
+// was registered with, and finally derived from the field name with the connection's NamingStrategy.
 //
 // Parameters:
-//   - fields: A slice of strings specifying the fields to perform virtual lookup.
+//   - ctx: The context.Context used for the aggregation and cursor iteration.
+//   - paths: The paths to perform virtual lookup on.
 //   - value: The interface{} value to be updated with the virtual lookup results.
 //   - filter: The filter to match documents for the virtual lookup.
 //
 // Returns:
 //   - interface{}: The updated value after the virtual lookup.
 //   - error: An error if any occurred during the virtual lookup process.
-func (qb *CollectQueryBuilder) virtual(fields []string, value interface{}, filter interface{}) (interface{}, error) {
+func (qb *CollectQueryBuilder) virtual(ctx context.Context, paths []*PopulatePath, value interface{}, filter interface{}) (interface{}, error) {
 	modelType, err := getModelType(value)
 	if err != nil {
 		return nil, err
 	}
-	modelInstance := reflect.New(modelType).Interface()
-	collectionName := strings.ToLower(modelType.Name()) + "s"
+
+	lookupStages, err := qb.populateStages(modelType, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineStages := append([]bson.D{{{Key: "$match", Value: filter}}}, lookupStages...)
+
+	// Run the lookup on the builder's own collection
+	cursor, err := qb.c.Aggregate(pipelineStages, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the virtual document exists
+	defer cursor.Close(ctx)
+
+	if cursor.Next(ctx) {
+		docs, err := qb.populateRefPaths(ctx, modelType, paths, []bson.Raw{cursor.Current})
+		if err != nil {
+			return nil, err
+		}
+
+		// Decode the virtual document
+		if err := bson.Unmarshal(docs[0], value); err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
+
+// populateStages builds the $lookup stages populating the paths of the model.
+// The stages can follow any stage producing documents of the model, so a single aggregation
+// populates every document of a query.
+//
+// Every lookup joins on localField/foreignField, so the server matches the references with an index on the
+// foreign field. Paths with options add a lookup pipeline, which requires MongoDB 5.0 or later, that applies
+// the path's match, sort and limit to the joined documents, populates nested paths on the populated model and
+// finally trims the documents to the selected fields.
+// Fields tagged with count:"true" are set to the number of matching documents, so they are typically
+// int fields with a ref tag naming the counted collection.
+//
+// Parameters:
+//   - modelType: The struct type of the documents being populated.
+//   - paths: The paths to populate.
+//
+// Returns:
+//   - []bson.D: The $lookup stages, each followed by an $addFields stage for justOne and count fields.
+//   - error: An error if a path does not exist in the model.
+func (qb *CollectQueryBuilder) populateStages(modelType reflect.Type, paths []*PopulatePath) ([]bson.D, error) {
+	merged, err := mergePopulatePaths(modelType, paths)
+	if err != nil {
+		return nil, err
+	}
 
 	var pipelineStages []bson.D
 
-	pipelineStages = append(pipelineStages, bson.D{{Key: "$match", Value: filter}})
+	for _, path := range merged {
+		structField, err := populateField(modelType, path.field)
+		if err != nil {
+			return nil, err
+		}
 
-	for _, field := range fields {
-		tags, err := getTags(modelType, field)
+		tags, err := getTags(modelType, structField.Name)
 		if err != nil {
 			return nil, err
 		}
 
+		// Dynamic references are populated from the query results by populateRefPaths
+		if tags["refPath"] != "" {
+			if len(path.populate) > 0 {
+				return nil, fmt.Errorf("cannot populate %s inside %s, the documents of refPath fields are not populated further", path.populate[0].field, path.field)
+			}
+			continue
+		}
+
 		localField := tags["localField"]
 		foreignField := tags["foreignField"]
 		justOne := tags["justOne"]
 		count := tags["count"]
+		as := tags["bson"]
+
+		from := qb.c.db.refCollection(structField)
 
-		lookupStage := bson.D{
-			{Key: "$lookup", Value: bson.D{
-				{Key: "from", Value: strings.ToLower(field) + "s"},
-				{Key: "localField", Value: localField},
-				{Key: "foreignField", Value: foreignField},
-				{Key: "as", Value: strings.ToLower(field)},
-			}},
+		lookup := bson.D{
+			{Key: "from", Value: from},
+			{Key: "localField", Value: localField},
+			{Key: "foreignField", Value: foreignField},
 		}
+		if count == "true" || path.hasOptions() {
+			pipeline, err := qb.populatePipeline(elemType(structField.Type), path, count == "true")
+			if err != nil {
+				return nil, err
+			}
+			lookup = append(lookup, bson.E{Key: "pipeline", Value: pipeline})
+		}
+		lookup = append(lookup, bson.E{Key: "as", Value: as})
 
-		pipelineStages = append(pipelineStages, lookupStage)
+		pipelineStages = append(pipelineStages, bson.D{{Key: "$lookup", Value: lookup}})
 
-		if count != "true" && justOne == "true" {
+		if count == "true" {
+			// Replace the { count: n } document returned by the lookup with the number, or 0 when nothing matched
+			pipelineStages = append(pipelineStages, bson.D{
+				{Key: "$addFields", Value: bson.M{
+					as: bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$" + as + ".count", 0}}, 0}},
+				}},
+			})
+		} else if justOne == "true" {
 			// Use $ifNull to conditionally set the virtual field based on whether it exists or not
 			pipelineStages = append(pipelineStages, bson.D{
 				{Key: "$addFields", Value: bson.M{
-					strings.ToLower(field): bson.M{"$arrayElemAt": bson.A{"$" + strings.ToLower(field), 0}},
+					as: bson.M{"$arrayElemAt": bson.A{"$" + as, 0}},
 				}},
 			})
 		}
 	}
 
-	collection, err := Collection(collectionName, modelInstance)
-	if err != nil {
-		return nil, err
-	}
+	return pipelineStages, nil
+}
 
-	cursor, err := collection.c.Aggregate(pipelineStages)
-	if err != nil {
-		return nil, err
+// populatePipeline builds the lookup pipeline of a path with options. The pipeline runs on the documents
+// already joined on localField/foreignField, so it only holds the stages of the path's options.
+// For count fields, the pipeline ends with a $count stage, so only the number of matching documents is returned.
+func (qb *CollectQueryBuilder) populatePipeline(refType reflect.Type, path *PopulatePath, count bool) (bson.A, error) {
+	pipeline := bson.A{}
+
+	if path.match != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: path.match}})
+	}
+	if path.sort != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: path.sort}})
+	}
+	if path.limit != 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: path.limit}})
+	}
+	if count {
+		return append(pipeline, bson.D{{Key: "$count", Value: "count"}}), nil
 	}
 
-	// Check if the virtual document exists
-	if cursor.Next(context.Background()) {
-		// Decode the virtual document
-		if err := cursor.Decode(value); err != nil {
+	var nested []string
+	if len(path.populate) > 0 {
+		if refType.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("cannot populate %s inside %s, which is not a struct", path.populate[0].field, path.field)
+		}
+
+		for _, nestedPath := range path.populate {
+			structField, err := populateField(refType, nestedPath.field)
+			if err != nil {
+				return nil, err
+			}
+			if structField.Tag.Get("refPath") != "" {
+				return nil, fmt.Errorf("cannot populate %s inside %s, refPath fields can only be populated on the queried model", nestedPath.field, path.field)
+			}
+		}
+
+		nestedStages, err := qb.populateStages(refType, path.populate)
+		if err != nil {
 			return nil, err
 		}
+		for _, stage := range nestedStages {
+			pipeline = append(pipeline, stage)
+		}
+
+		for _, nestedPath := range path.populate {
+			structField, _ := populateField(refType, nestedPath.field)
+			if name, _, _ := bsonFieldName(structField); !containsString(nested, name) {
+				nested = append(nested, name)
+			}
+		}
 	}
 
-	return value, nil
+	if projection := path.projection(nested); projection != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: projection}})
+	}
+
+	return pipeline, nil
 }
@@ -0,0 +1,107 @@
+package morm
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type lookupAuthor struct {
+	Model     `bson:",inline"`
+	Name      string `bson:"name"`
+	PostCount int    `bson:"postCount" ref:"posts" localField:"_id" foreignField:"authorId" count:"true"`
+}
+
+type lookupPost struct {
+	Model    `bson:",inline"`
+	AuthorID primitive.ObjectID `bson:"authorId"`
+	Author   *lookupAuthor      `bson:"author" ref:"authors" localField:"authorId" foreignField:"_id" justOne:"true"`
+}
+
+// TestPopulateStages tests that lookups join on localField/foreignField and only add the stages of their options
+func TestPopulateStages(t *testing.T) {
+	qb := &CollectQueryBuilder{c: &Collect{db: &DB{}}}
+
+	tests := []struct {
+		name     string
+		path     *PopulatePath
+		pipeline bson.A
+	}{
+		{
+			name:     "plain",
+			path:     Path("Author"),
+			pipeline: nil,
+		},
+		{
+			name: "options",
+			path: Path("Author").Match(bson.M{"name": "ada"}).Sort(bson.D{{Key: "name", Value: 1}}).Limit(1),
+			pipeline: bson.A{
+				bson.D{{Key: "$match", Value: bson.M{"name": "ada"}}},
+				bson.D{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}}}},
+				bson.D{{Key: "$limit", Value: int64(1)}},
+			},
+		},
+		{
+			name: "nested count",
+			path: Path("Author").Populate("PostCount"),
+			pipeline: bson.A{
+				bson.D{{Key: "$lookup", Value: bson.D{
+					{Key: "from", Value: "posts"},
+					{Key: "localField", Value: "_id"},
+					{Key: "foreignField", Value: "authorId"},
+					{Key: "pipeline", Value: bson.A{bson.D{{Key: "$count", Value: "count"}}}},
+					{Key: "as", Value: "postCount"},
+				}}},
+				bson.D{{Key: "$addFields", Value: bson.M{
+					"postCount": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$postCount.count", 0}}, 0}},
+				}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stages, err := qb.populateStages(reflect.TypeOf(lookupPost{}), []*PopulatePath{tt.path})
+			if err != nil {
+				t.Fatalf("Failed to build stages: %v", err)
+			}
+
+			lookup := bson.D{
+				{Key: "from", Value: "authors"},
+				{Key: "localField", Value: "authorId"},
+				{Key: "foreignField", Value: "_id"},
+			}
+			if tt.pipeline != nil {
+				lookup = append(lookup, bson.E{Key: "pipeline", Value: tt.pipeline})
+			}
+			lookup = append(lookup, bson.E{Key: "as", Value: "author"})
+
+			if len(stages) != 2 || !reflect.DeepEqual(stages[0], bson.D{{Key: "$lookup", Value: lookup}}) {
+				t.Fatalf("Expected the lookup %v, got %v", lookup, stages)
+			}
+		})
+	}
+}
+
+type lookupActivity struct {
+	Model      `bson:",inline"`
+	TargetID   primitive.ObjectID `bson:"targetId"`
+	TargetType string             `bson:"targetType"`
+	Target     bson.Raw           `bson:"target" refPath:"targetType" localField:"targetId" foreignField:"_id" justOne:"true"`
+}
+
+// TestPopulateStagesRefPath tests that refPath paths get no lookup and cannot populate nested paths
+func TestPopulateStagesRefPath(t *testing.T) {
+	qb := &CollectQueryBuilder{c: &Collect{db: &DB{}}}
+	modelType := reflect.TypeOf(lookupActivity{})
+
+	stages, err := qb.populateStages(modelType, []*PopulatePath{Path("Target").Sort(bson.D{{Key: "name", Value: 1}})})
+	if err != nil || len(stages) != 0 {
+		t.Fatalf("Expected no lookup stages, got %v (%v)", stages, err)
+	}
+
+	if _, err := qb.populateStages(modelType, []*PopulatePath{Path("Target").Populate("Author")}); err == nil {
+		t.Fatal("Expected a nested populate under a refPath path to be rejected")
+	}
+}